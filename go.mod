@@ -43,7 +43,9 @@ require (
 	go.opentelemetry.io/otel/trace v1.20.0
 	go.uber.org/atomic v1.7.0
 	go.uber.org/goleak v1.3.1-0.20241121203838-4ff5fa6529ee
+	golang.org/x/crypto v0.35.0
 	golang.org/x/net v0.36.0
+	golang.org/x/time v0.3.0
 	gonum.org/v1/gonum v0.6.2
 	google.golang.org/grpc v1.60.1
 	google.golang.org/protobuf v1.33.0
@@ -97,12 +99,10 @@ require (
 	github.com/zeebo/errs v1.3.0 // indirect
 	go.etcd.io/bbolt v1.3.6 // indirect
 	go.opentelemetry.io/proto/otlp v1.0.0 // indirect
-	golang.org/x/crypto v0.35.0 // indirect
 	golang.org/x/mod v0.17.0 // indirect
 	golang.org/x/sync v0.11.0 // indirect
 	golang.org/x/sys v0.30.0 // indirect
 	golang.org/x/text v0.22.0 // indirect
-	golang.org/x/time v0.3.0 // indirect
 	golang.org/x/tools v0.21.1-0.20240508182429-e35e4ccd0d2d // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20231012201019-e917dd12ba7a // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20231030173426-d783a09b4405 // indirect
@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlanipam
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/ipam"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/kernel/vlan"
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+const restoreInterval = 200 * time.Millisecond
+
+type lease struct {
+	trunk  string
+	vlanID uint32
+}
+
+type leaseStream struct {
+	ipam.IPAM_ManagePrefixesClient
+	ctx       context.Context
+	cancel    context.CancelFunc
+	responses chan *ipam.PrefixResponse
+	done      chan struct{}
+}
+
+type allocator struct {
+	chainCtx       context.Context
+	client         ipam.IPAMClient
+	restoreTimeout time.Duration
+	leases         map[lease]struct{}
+	stream         *leaseStream
+	lock           sync.Mutex
+}
+
+// NewAllocator - returns a vlan.Allocator reserving VLAN IDs through the central VLAN IPAM service on cc.
+// All the allocated IDs are held by a single ManagePrefixes stream living till chainCtx is done. If the stream breaks,
+// it is re-established and the held IDs are allocated again.
+func NewAllocator(chainCtx context.Context, cc grpc.ClientConnInterface, opts ...Option) vlan.Allocator {
+	o := &options{
+		restoreTimeout: 30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &allocator{
+		chainCtx:       chainCtx,
+		client:         ipam.NewIPAMClient(cc),
+		restoreTimeout: o.restoreTimeout,
+		leases:         make(map[lease]struct{}),
+	}
+}
+
+func (a *allocator) Allocate(ctx context.Context, trunk string, vlanID uint32) (uint32, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.stream == nil {
+		if err := a.connect(ctx, false); err != nil {
+			return 0, err
+		}
+	}
+
+	vlanID, err := a.stream.allocate(ctx, trunk, vlanID)
+	if err != nil {
+		// The response may still come, so the stream can't be used anymore
+		a.stream.cancel()
+		a.stream = nil
+		return 0, err
+	}
+	if vlanID == 0 {
+		return 0, errors.Errorf("vlan id not available for allocation on trunk %q", trunk)
+	}
+
+	a.leases[lease{trunk: trunk, vlanID: vlanID}] = struct{}{}
+	return vlanID, nil
+}
+
+func (a *allocator) Release(_ context.Context, trunk string, vlanID uint32) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	l := lease{trunk: trunk, vlanID: vlanID}
+	if _, ok := a.leases[l]; !ok {
+		return errors.Errorf("vlan id %d is not allocated on trunk %q", vlanID, trunk)
+	}
+	delete(a.leases, l)
+
+	// The service releases all the IDs of the broken stream by itself
+	if a.stream != nil {
+		if err := a.stream.release(trunk, vlanID); err != nil {
+			a.stream.cancel()
+			a.stream = nil
+		}
+	}
+	return nil
+}
+
+// connect opens a new stream and allocates the held IDs on it. If dropLost is false, it fails when any of the held IDs
+// is taken, otherwise such IDs are dropped with an error.
+func (a *allocator) connect(ctx context.Context, dropLost bool) error {
+	streamCtx, cancel := context.WithCancel(a.chainCtx)
+	client, err := a.client.ManagePrefixes(streamCtx)
+	if err != nil {
+		cancel()
+		return errors.Wrap(err, "failed to start managing vlan ids")
+	}
+
+	s := &leaseStream{
+		IPAM_ManagePrefixesClient: client,
+		ctx:                       streamCtx,
+		cancel:                    cancel,
+		responses:                 make(chan *ipam.PrefixResponse),
+		done:                      make(chan struct{}),
+	}
+	go a.receive(s)
+
+	for l := range a.leases {
+		vlanID, err := s.allocate(ctx, l.trunk, l.vlanID)
+		if err == nil && vlanID != l.vlanID {
+			if vlanID != 0 {
+				err = s.release(l.trunk, vlanID)
+			}
+			if err == nil && dropLost {
+				log.FromContext(a.chainCtx).Errorf("vlan id %d on trunk %q is lost: it is taken by another client", l.vlanID, l.trunk)
+				delete(a.leases, l)
+				continue
+			}
+			if err == nil {
+				err = errors.Errorf("failed to restore vlan id %d on trunk %q", l.vlanID, l.trunk)
+			}
+		}
+		if err != nil {
+			cancel()
+			return err
+		}
+	}
+
+	a.stream = s
+	return nil
+}
+
+func (a *allocator) receive(s *leaseStream) {
+	defer a.restore(s)
+	defer s.cancel()
+	defer close(s.done)
+
+	for {
+		resp, err := s.Recv()
+		if err != nil {
+			return
+		}
+		select {
+		case s.responses <- resp:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// restore re-establishes the broken stream s if it is still in use
+func (a *allocator) restore(s *leaseStream) {
+	logger := log.FromContext(a.chainCtx).WithField("vlanipam", "restore")
+	clk := clock.FromContext(a.chainCtx)
+	deadline := clk.Now().Add(a.restoreTimeout)
+
+	a.lock.Lock()
+	if a.stream != s {
+		a.lock.Unlock()
+		return
+	}
+	a.stream = nil
+	a.lock.Unlock()
+
+	for a.chainCtx.Err() == nil {
+		a.lock.Lock()
+		if a.stream != nil || len(a.leases) == 0 {
+			a.lock.Unlock()
+			return
+		}
+		err := a.connect(a.chainCtx, !clk.Now().Before(deadline))
+		a.lock.Unlock()
+
+		if err == nil {
+			logger.Infof("vlan ids are restored")
+			return
+		}
+		logger.Warnf("failed to restore vlan ids: %v", err.Error())
+
+		select {
+		case <-a.chainCtx.Done():
+		case <-clk.After(restoreInterval):
+		}
+	}
+}
+
+// allocate returns the allocated VLAN ID, 0 if there is no VLAN ID available on the trunk
+func (s *leaseStream) allocate(ctx context.Context, trunk string, vlanID uint32) (uint32, error) {
+	if err := s.Send(&ipam.PrefixRequest{Type: ipam.Type_ALLOCATE, Prefix: vlanPrefix(trunk, vlanID)}); err != nil {
+		return 0, errors.Wrap(err, "failed to allocate vlan id")
+	}
+
+	select {
+	case <-ctx.Done():
+		return 0, errors.Wrap(ctx.Err(), "failed to allocate vlan id")
+	case <-s.done:
+		return 0, errors.New("failed to allocate vlan id: stream is closed")
+	case resp := <-s.responses:
+		respTrunk, respVLANID, err := parseVLANPrefix(resp.GetPrefix())
+		if err != nil {
+			return 0, err
+		}
+		if respTrunk != trunk {
+			return 0, errors.Errorf("unexpected vlan id %d allocated on trunk %q", respVLANID, respTrunk)
+		}
+		return respVLANID, nil
+	}
+}
+
+func (s *leaseStream) release(trunk string, vlanID uint32) error {
+	return errors.Wrap(
+		s.Send(&ipam.PrefixRequest{Type: ipam.Type_DELETE, Prefix: vlanPrefix(trunk, vlanID)}),
+		"failed to release vlan id")
+}
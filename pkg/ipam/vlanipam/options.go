@@ -0,0 +1,35 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlanipam
+
+import "time"
+
+type options struct {
+	restoreTimeout time.Duration
+}
+
+// Option is an option pattern for NewAllocator
+type Option func(o *options)
+
+// WithRestoreTimeout sets how long the allocator keeps trying to re-allocate the held VLAN IDs after the stream to
+// the service breaks. The IDs still taken after the timeout (e.g. by another forwarder) are dropped with an error.
+// Default: 30s
+func WithRestoreTimeout(restoreTimeout time.Duration) Option {
+	return func(o *options) {
+		o.restoreTimeout = restoreTimeout
+	}
+}
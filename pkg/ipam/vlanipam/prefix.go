@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlanipam
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// vlanPrefix returns the ipam.PrefixRequest/PrefixResponse prefix for the VLAN ID on the trunk: "<trunk>/<vlan id>".
+// In the request 0 VLAN ID means any, in the response it means that there is no VLAN ID available.
+func vlanPrefix(trunk string, vlanID uint32) string {
+	return fmt.Sprintf("%s/%d", trunk, vlanID)
+}
+
+func parseVLANPrefix(prefix string) (trunk string, vlanID uint32, err error) {
+	i := strings.LastIndex(prefix, "/")
+	if i < 0 {
+		return "", 0, errors.Errorf("invalid vlan prefix: %q", prefix)
+	}
+	id, err := strconv.ParseUint(prefix[i+1:], 10, 16)
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "invalid vlan prefix: %q", prefix)
+	}
+	return prefix[:i], uint32(id), nil
+}
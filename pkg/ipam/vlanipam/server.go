@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vlanipam provides a central VLAN ID allocator service, so forwarders sharing a trunk (physical network)
+// never pick the same VLAN ID, and a vlan.Allocator using it.
+//
+// The service is ipam.IPAMServer: VLAN IDs are allocated and deleted over the ManagePrefixes stream with the
+// "<trunk>/<vlan id>" prefixes and stay reserved till they are deleted or the stream is closed.
+package vlanipam
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/ipam"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/kernel/vlan"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+type vlanIPAMServer struct {
+	allocator vlan.Allocator
+}
+
+// NewServer creates a new ipam.IPAMServer handler for grpc.Server reserving VLAN IDs from the ranges
+// (1-4094 by default) per trunk
+func NewServer(ranges ...vlan.Range) ipam.IPAMServer {
+	return &vlanIPAMServer{
+		allocator: vlan.NewLocalAllocator(ranges...),
+	}
+}
+
+func (s *vlanIPAMServer) ManagePrefixes(prefixServer ipam.IPAM_ManagePrefixesServer) error {
+	ctx := prefixServer.Context()
+	logger := log.FromContext(ctx).WithField("ID", uuid.New().String())
+
+	leases := make(map[lease]struct{})
+	defer func() {
+		for l := range leases {
+			_ = s.allocator.Release(ctx, l.trunk, l.vlanID)
+		}
+		logger.Debugf("Disconnected, released: %v", leases)
+	}()
+
+	for {
+		r, err := prefixServer.Recv()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errors.Wrap(err, "failed to manage vlan ids")
+		}
+
+		trunk, vlanID, err := parseVLANPrefix(r.GetPrefix())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		switch r.GetType() {
+		case ipam.Type_ALLOCATE:
+			if vlanID, err = s.allocator.Allocate(ctx, trunk, vlanID); err != nil {
+				logger.Warnf("Failed to allocate: %v", err.Error())
+				vlanID = 0
+			} else {
+				leases[lease{trunk: trunk, vlanID: vlanID}] = struct{}{}
+				logger.Debugf("Allocated: trunk %q, vlan id %d", trunk, vlanID)
+			}
+			if err := prefixServer.Send(&ipam.PrefixResponse{Prefix: vlanPrefix(trunk, vlanID)}); err != nil {
+				return errors.Wrap(err, "failed to send allocated vlan id")
+			}
+		case ipam.Type_DELETE:
+			l := lease{trunk: trunk, vlanID: vlanID}
+			if _, ok := leases[l]; ok {
+				delete(leases, l)
+				_ = s.allocator.Release(ctx, trunk, vlanID)
+				logger.Debugf("Released: trunk %q, vlan id %d", trunk, vlanID)
+			}
+		default:
+			return status.Error(codes.InvalidArgument, "request type is undefined")
+		}
+	}
+}
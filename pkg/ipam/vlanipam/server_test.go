@@ -0,0 +1,185 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlanipam_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/networkservicemesh/api/pkg/api/ipam"
+
+	"github.com/ljkiraly/sdk/pkg/ipam/vlanipam"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/kernel/vlan"
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+)
+
+func newVLANIPAMServer(ctx context.Context, t *testing.T, ranges ...vlan.Range) url.URL {
+	var serverAddr = url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	serveVLANIPAM(ctx, t, &serverAddr, ranges...)
+	return serverAddr
+}
+
+func serveVLANIPAM(ctx context.Context, t *testing.T, serverAddr *url.URL, ranges ...vlan.Range) <-chan error {
+	var s = grpc.NewServer()
+	ipam.RegisterIPAMServer(s, vlanipam.NewServer(ranges...))
+
+	errCh := grpcutils.ListenAndServe(ctx, serverAddr, s)
+	require.Len(t, errCh, 0)
+
+	return errCh
+}
+
+func newAllocator(ctx context.Context, t *testing.T, connectTO *url.URL) vlan.Allocator {
+	var cc, err = grpc.DialContext(
+		ctx, grpcutils.URLToTarget(connectTO),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	go func() {
+		<-ctx.Done()
+		_ = cc.Close()
+	}()
+
+	return vlanipam.NewAllocator(ctx, cc)
+}
+
+func Test_VLAN_IPAM_SharedTrunk(t *testing.T) {
+	t.Cleanup(func() {
+		goleak.VerifyNone(t)
+	})
+
+	var ctx, cancel = context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	connectTO := newVLANIPAMServer(ctx, t, vlan.Range{Trunk: "trunk", Min: 100, Max: 102})
+
+	forwarder1 := newAllocator(ctx, t, &connectTO)
+	forwarder2 := newAllocator(ctx, t, &connectTO)
+
+	vlanID, err := forwarder1.Allocate(ctx, "trunk", 0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID)
+
+	// The other forwarder on the same trunk can't take the used ID, it gets another one
+	_, err = forwarder2.Allocate(ctx, "trunk", 100)
+	require.Error(t, err)
+	vlanID, err = forwarder2.Allocate(ctx, "trunk", 0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(101), vlanID)
+
+	// Recovery
+	vlanID, err = forwarder2.Allocate(ctx, "trunk", 102)
+	require.NoError(t, err)
+	require.Equal(t, uint32(102), vlanID)
+
+	_, err = forwarder1.Allocate(ctx, "trunk", 0)
+	require.Error(t, err)
+
+	// Other trunks use the default range
+	vlanID, err = forwarder1.Allocate(ctx, "other", 0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), vlanID)
+
+	require.NoError(t, forwarder2.Release(ctx, "trunk", 101))
+	require.Error(t, forwarder2.Release(ctx, "trunk", 101))
+
+	require.Eventually(t, func() bool {
+		vlanID, err = forwarder1.Allocate(ctx, "trunk", 0)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, uint32(101), vlanID)
+}
+
+func Test_VLAN_IPAM_ReleaseOnDisconnect(t *testing.T) {
+	t.Cleanup(func() {
+		goleak.VerifyNone(t)
+	})
+
+	var ctx, cancel = context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	connectTO := newVLANIPAMServer(ctx, t, vlan.Range{Trunk: "trunk", Min: 100, Max: 100})
+
+	forwarderCtx, cancelForwarder := context.WithCancel(ctx)
+	forwarder1 := newAllocator(forwarderCtx, t, &connectTO)
+
+	vlanID, err := forwarder1.Allocate(ctx, "trunk", 0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID)
+
+	forwarder2 := newAllocator(ctx, t, &connectTO)
+	_, err = forwarder2.Allocate(ctx, "trunk", 0)
+	require.Error(t, err)
+
+	// IDs of a gone forwarder are released
+	cancelForwarder()
+
+	require.Eventually(t, func() bool {
+		vlanID, err = forwarder2.Allocate(ctx, "trunk", 0)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, uint32(100), vlanID)
+}
+
+func Test_VLAN_IPAM_RestoreOnServerRestart(t *testing.T) {
+	t.Cleanup(func() {
+		goleak.VerifyNone(t)
+	})
+
+	var ctx, cancel = context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	serverCtx, cancelServer := context.WithCancel(ctx)
+	connectTO := url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	serverErrCh := serveVLANIPAM(serverCtx, t, &connectTO, vlan.Range{Trunk: "trunk", Min: 100, Max: 101})
+
+	forwarder1 := newAllocator(ctx, t, &connectTO)
+
+	vlanID, err := forwarder1.Allocate(ctx, "trunk", 0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID)
+
+	// The restarted service has no state, the forwarder allocates the held ID again
+	cancelServer()
+	<-serverErrCh
+	serveVLANIPAM(ctx, t, &connectTO, vlan.Range{Trunk: "trunk", Min: 100, Max: 101})
+
+	require.Eventually(t, func() bool {
+		vlanID, err = forwarder1.Allocate(ctx, "trunk", 0)
+		return err == nil
+	}, time.Second*5, 10*time.Millisecond)
+	require.Equal(t, uint32(101), vlanID)
+
+	forwarder2 := newAllocator(ctx, t, &connectTO)
+	_, err = forwarder2.Allocate(ctx, "trunk", 100)
+	require.Error(t, err)
+
+	require.NoError(t, forwarder1.Release(ctx, "trunk", 100))
+	require.Eventually(t, func() bool {
+		vlanID, err = forwarder2.Allocate(ctx, "trunk", 100)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, uint32(100), vlanID)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/clienturl"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/connect"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/dial"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/history"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/null"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/refresh"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/trimpath"
@@ -40,22 +41,31 @@ import (
 //   - ctx    - context for the lifecycle of the *Client* itself.  Cancel when discarding the client.
 func NewClient(ctx context.Context, clientOpts ...Option) networkservice.NetworkServiceClient {
 	var opts = &clientOptions{
-		name:            "client-" + uuid.New().String(),
-		authorizeClient: null.NewClient(),
-		healClient:      null.NewClient(),
-		refreshClient:   refresh.NewClient(ctx),
-		reselectFunc:    begin.DefaultReselectFunc,
+		name:               "client-" + uuid.New().String(),
+		authorizeClient:    null.NewClient(),
+		healClient:         null.NewClient(),
+		standbyClient:      null.NewClient(),
+		shareClient:        null.NewClient(),
+		shareMonitorClient: null.NewClient(),
+		reselectFunc:       begin.DefaultReselectFunc,
 	}
 	for _, opt := range clientOpts {
 		opt(opts)
 	}
+	if opts.refreshClient == nil {
+		opts.refreshClient = refresh.NewClient(ctx, opts.refreshOptions...)
+	}
 
 	return chain.NewNetworkServiceClient(
 		append(
 			[]networkservice.NetworkServiceClient{
+				opts.shareClient,
 				updatepath.NewClient(opts.name),
 				begin.NewClient(begin.WithReselectFunc(opts.reselectFunc)),
+				opts.shareMonitorClient,
+				opts.standbyClient,
 				metadata.NewClient(),
+				history.NewClient(),
 				opts.refreshClient,
 				clienturl.NewClient(opts.clientURL),
 				clientconn.NewClient(opts.cc),
@@ -25,6 +25,8 @@ import (
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/null"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/refresh"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/share"
 )
 
 type clientOptions struct {
@@ -34,7 +36,11 @@ type clientOptions struct {
 	additionalFunctionality []networkservice.NetworkServiceClient
 	authorizeClient         networkservice.NetworkServiceClient
 	refreshClient           networkservice.NetworkServiceClient
+	refreshOptions          []refresh.Option
 	healClient              networkservice.NetworkServiceClient
+	standbyClient           networkservice.NetworkServiceClient
+	shareClient             networkservice.NetworkServiceClient
+	shareMonitorClient      networkservice.NetworkServiceClient
 	dialOptions             []grpc.DialOption
 	dialTimeout             time.Duration
 	reselectFunc            begin.ReselectFunc
@@ -91,6 +97,28 @@ func WithHealClient(healClient networkservice.NetworkServiceClient) Option {
 	})
 }
 
+// WithStandbyClient sets standbyClient for the client chain. Use it together with make-before-break heal client.
+func WithStandbyClient(standbyClient networkservice.NetworkServiceClient) Option {
+	if standbyClient == nil {
+		panic("standbyClient cannot be nil")
+	}
+	return Option(func(c *clientOptions) {
+		c.standbyClient = standbyClient
+	})
+}
+
+// WithConnectionSharing enables sharing of a single connection between identical requests using the pool. The same
+// pool may be passed to several clients.
+func WithConnectionSharing(pool *share.Pool) Option {
+	if pool == nil {
+		panic("pool cannot be nil")
+	}
+	return Option(func(c *clientOptions) {
+		c.shareClient = share.NewClient(pool)
+		c.shareMonitorClient = share.NewMonitorClient(pool)
+	})
+}
+
 // WithDialOptions sets dial options
 func WithDialOptions(dialOptions ...grpc.DialOption) Option {
 	return Option(func(c *clientOptions) {
@@ -112,6 +140,13 @@ func WithoutRefresh() Option {
 	}
 }
 
+// WithRefreshOptions sets options for the refresh client: jitter, rate limiter
+func WithRefreshOptions(refreshOptions ...refresh.Option) Option {
+	return func(c *clientOptions) {
+		c.refreshOptions = refreshOptions
+	}
+}
+
 // WithReselectFunc sets a function for changing request parameters on reselect
 func WithReselectFunc(f func(*networkservice.NetworkServiceRequest)) Option {
 	return func(c *clientOptions) {
@@ -30,12 +30,15 @@ import (
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/history"
 
 	"github.com/google/uuid"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/admin"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/authorize"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/monitor"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/null"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/timeout"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/updatepath"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/updatetoken"
@@ -59,13 +62,16 @@ type Endpoint interface {
 type endpoint struct {
 	networkservice.NetworkServiceServer
 	networkservice.MonitorConnectionServer
+	adminServer *admin.Server
 }
 
 type serverOptions struct {
 	name                             string
 	authorizeServer                  networkservice.NetworkServiceServer
 	authorizeMonitorConnectionServer networkservice.MonitorConnectionServer
+	revokeServer                     networkservice.NetworkServiceServer
 	additionalFunctionality          []networkservice.NetworkServiceServer
+	adminServer                      *admin.Server
 }
 
 // Option modifies server option value
@@ -98,6 +104,28 @@ func WithAuthorizeMonitorConnectionServer(authorizeMonitorConnectionServer netwo
 	}
 }
 
+// WithRevokeServer sets the revoke chain element, it is placed right after the authorization server. Build it with the
+// same spiffeIDConnectionMap as the authorization server, so that the connections of the revoked peers are closed.
+func WithRevokeServer(revokeServer networkservice.NetworkServiceServer) Option {
+	if revokeServer == nil {
+		panic("revokeServer cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.revokeServer = revokeServer
+	}
+}
+
+// WithAdminServer sets the admin chain element keeping the established connections. Its admin API is registered
+// with Register next to the endpoint services.
+func WithAdminServer(adminServer *admin.Server) Option {
+	if adminServer == nil {
+		panic("adminServer cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.adminServer = adminServer
+	}
+}
+
 // WithAdditionalFunctionality sets additional NetworkServiceServer chain elements to be included in the chain
 func WithAdditionalFunctionality(additionalFunctionality ...networkservice.NetworkServiceServer) Option {
 	return func(o *serverOptions) {
@@ -111,23 +139,34 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 		name:                             "endpoint-" + uuid.New().String(),
 		authorizeServer:                  authorize.NewServer(authorize.Any()),
 		authorizeMonitorConnectionServer: authmonitor.NewMonitorConnectionServer(authmonitor.Any()),
+		revokeServer:                     null.NewServer(),
 	}
 	for _, opt := range options {
 		opt(opts)
 	}
 	var mcsPtr networkservice.MonitorConnectionServer
 
-	rv := &endpoint{}
+	var adminServer networkservice.NetworkServiceServer = null.NewServer()
+	if opts.adminServer != nil {
+		adminServer = opts.adminServer
+	}
+
+	rv := &endpoint{
+		adminServer: opts.adminServer,
+	}
 	rv.NetworkServiceServer = chain.NewNetworkServiceServer(
 		append([]networkservice.NetworkServiceServer{
 			updatepath.NewServer(opts.name),
 			begin.NewServer(),
 			updatetoken.NewServer(tokenGenerator),
 			opts.authorizeServer,
+			opts.revokeServer,
 			metadata.NewServer(),
+			history.NewServer(),
 			timeout.NewServer(ctx),
 			monitor.NewServer(ctx, &mcsPtr),
 			trimpath.NewServer(),
+			adminServer,
 		}, opts.additionalFunctionality...)...)
 	rv.MonitorConnectionServer = next.NewMonitorConnectionServer(opts.authorizeMonitorConnectionServer, mcsPtr)
 	return rv
@@ -137,6 +176,9 @@ func (e *endpoint) Register(s *grpc.Server) {
 	grpcutils.RegisterHealthServices(s, e)
 	networkservice.RegisterNetworkServiceServer(s, e)
 	networkservice.RegisterMonitorConnectionServer(s, e)
+	if e.adminServer != nil {
+		e.adminServer.Register(s)
+	}
 }
 
 // Serve  - serves passed Endpoint on grpc
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/chains/endpoint"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/authorize"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/revoke"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
+	"github.com/ljkiraly/sdk/pkg/tools/sandbox"
+)
+
+func withPeer(ctx context.Context, t *testing.T, spiffeID string) context.Context {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	id, err := url.Parse(spiffeID)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{id},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return peer.NewContext(ctx, &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+			},
+		},
+	})
+}
+
+func newPeerRequest(t *testing.T, connID string) *networkservice.NetworkServiceRequest {
+	token, expires, err := sandbox.GenerateTestToken(nil)
+	require.NoError(t, err)
+
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             connID,
+			NetworkService: "ns",
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{
+					Name:    "nsmgr",
+					Id:      connID,
+					Token:   token,
+					Expires: timestamppb.New(expires),
+				}},
+			},
+		},
+	}
+}
+
+func TestEndpoint_RevokeClosesPeerConnections(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	spiffeIDConnectionMap := new(genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]])
+	list := revocation.NewList(nil, nil)
+	counter := new(count.Server)
+
+	e := endpoint.NewServer(ctx, sandbox.GenerateTestToken,
+		endpoint.WithName("nse"),
+		endpoint.WithAuthorizeServer(authorize.NewServer(
+			authorize.Any(),
+			authorize.WithSpiffeIDConnectionMap(spiffeIDConnectionMap),
+		)),
+		endpoint.WithRevokeServer(revoke.NewServer(ctx, list, spiffeIDConnectionMap)),
+		endpoint.WithAdditionalFunctionality(counter),
+	)
+
+	for _, peerID := range []string{"spiffe://test.com/nsmgr-1", "spiffe://test.com/nsmgr-2"} {
+		_, err := e.Request(withPeer(ctx, t, peerID), newPeerRequest(t, peerID))
+		require.NoError(t, err)
+	}
+
+	// Only the connection from the revoked peer is closed
+	list.Revoke([]string{"spiffe://test.com/nsmgr-1"}, nil)
+	require.Eventually(t, func() bool { return counter.Closes() == 1 }, time.Second, time.Millisecond*10)
+	require.Never(t, func() bool { return counter.Closes() > 1 }, time.Millisecond*100, time.Millisecond*10)
+
+	// The revoked peer can't connect again
+	_, err := e.Request(withPeer(ctx, t, "spiffe://test.com/nsmgr-1"), newPeerRequest(t, "new"))
+	require.Error(t, err)
+}
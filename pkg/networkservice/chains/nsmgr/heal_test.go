@@ -37,6 +37,7 @@ import (
 	nsclient "github.com/ljkiraly/sdk/pkg/networkservice/chains/client"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/heal"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/null"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkclose"
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkrequest"
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkresponse"
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
@@ -249,6 +250,74 @@ func TestNSMGRHealEndpoint_DataPlaneBroken_CtrlPlaneHealthy(t *testing.T) {
 	require.Equal(t, closes+1, counter.UniqueCloses())
 }
 
+func TestNSMGRHealEndpoint_MakeBeforeBreak(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+
+	defer cancel()
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetNSMgrProxySupplier(nil).
+		SetRegistryProxySupplier(nil).
+		Build()
+
+	nsRegistryClient := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken)
+
+	nsReg, err := nsRegistryClient.Register(ctx, defaultRegistryService(t.Name()))
+	require.NoError(t, err)
+
+	nseReg := defaultRegistryEndpoint(nsReg.Name)
+
+	counter1 := new(count.Server)
+	domain.Nodes[0].NewEndpoint(ctx, nseReg, sandbox.GenerateTestToken, counter1)
+
+	request := defaultRequest(nsReg.Name)
+
+	// Data plane is broken for the first NSE only
+	livenessCheck := func(ctx context.Context, conn *networkservice.Connection) bool {
+		return conn.GetNetworkServiceEndpointName() != nseReg.Name
+	}
+
+	counter2 := new(count.Server)
+	var connID atomic.String
+	var requestsBeforeClose atomic.Int32
+	requestsBeforeClose.Store(-1)
+
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken,
+		nsclient.WithHealClient(heal.NewClient(ctx,
+			heal.WithLivenessCheck(livenessCheck),
+			heal.WithMakeBeforeBreak())),
+		nsclient.WithAdditionalFunctionality(checkclose.NewClient(t, func(_ *testing.T, conn *networkservice.Connection) {
+			if conn.GetId() == connID.Load() {
+				requestsBeforeClose.Store(int32(counter2.UniqueRequests()))
+			}
+		})))
+
+	conn, err := nsc.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	connID.Store(conn.GetId())
+	require.Equal(t, 1, counter1.UniqueRequests())
+
+	nseReg2 := defaultRegistryEndpoint(nsReg.Name)
+	nseReg2.Name += "-2"
+	domain.Nodes[0].NewEndpoint(ctx, nseReg2, sandbox.GenerateTestToken, counter2)
+
+	// The old connection is closed only after the new one is established
+	require.Eventually(t, func() bool { return requestsBeforeClose.Load() >= 0 }, timeout, tick)
+	require.Equal(t, int32(1), requestsBeforeClose.Load())
+	require.Equal(t, 0, counter2.UniqueCloses())
+
+	// Refresh and Close with the old connection are applied to the new one
+	request.Connection = conn
+	conn, err = nsc.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	require.Equal(t, nseReg2.Name, conn.GetNetworkServiceEndpointName())
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, 1, counter2.UniqueCloses())
+}
+
 func TestNSMGRHealEndpoint_DatapathHealthy_CtrlPlaneBroken(t *testing.T) {
 	t.Skip("https://github.com/ljkiraly/sdk/issues/1573")
 
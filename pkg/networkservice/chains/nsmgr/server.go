@@ -33,6 +33,7 @@ import (
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/chains/client"
 	"github.com/ljkiraly/sdk/pkg/networkservice/chains/endpoint"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/admin"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/authorize"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/clientinfo"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/connect"
@@ -76,11 +77,13 @@ type Nsmgr interface {
 type nsmgrServer struct {
 	endpoint.Endpoint
 	registry.Registry
+	adminServer *admin.Server
 }
 
 type serverOptions struct {
 	authorizeServer                  networkservice.NetworkServiceServer
 	authorizeMonitorConnectionServer networkservice.MonitorConnectionServer
+	revokeServer                     networkservice.NetworkServiceServer
 	authorizeNSRegistryServer        registryapi.NetworkServiceRegistryServer
 	authorizeNSRegistryClient        registryapi.NetworkServiceRegistryClient
 	authorizeNSERegistryServer       registryapi.NetworkServiceEndpointRegistryServer
@@ -92,6 +95,7 @@ type serverOptions struct {
 	name                             string
 	url                              string
 	forwarderServiceName             string
+	adminServer                      *admin.Server
 }
 
 // Option modifies server option value
@@ -146,6 +150,17 @@ func WithAuthorizeMonitorConnectionServer(authorizeMonitorConnectionServer netwo
 	}
 }
 
+// WithRevokeServer sets the revoke chain element, it is placed right after the authorization server. Build it with the
+// same spiffeIDConnectionMap as the authorization server, so that the connections of the revoked peers are closed.
+func WithRevokeServer(revokeServer networkservice.NetworkServiceServer) Option {
+	if revokeServer == nil {
+		panic("revokeServer cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.revokeServer = revokeServer
+	}
+}
+
 // WithAuthorizeNSRegistryServer sets authorization NetworkServiceRegistry chain element
 func WithAuthorizeNSRegistryServer(authorizeNSRegistryServer registryapi.NetworkServiceRegistryServer) Option {
 	if authorizeNSRegistryServer == nil {
@@ -186,6 +201,16 @@ func WithAuthorizeNSERegistryClient(authorizeNSERegistryClient registryapi.Netwo
 	}
 }
 
+// WithAdminServer sets the admin chain element exposing the connections admin API with Register
+func WithAdminServer(adminServer *admin.Server) Option {
+	if adminServer == nil {
+		panic("adminServer cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.adminServer = adminServer
+	}
+}
+
 // WithRegistry sets URL and dial options to reach the upstream registry, if not passed memory storage will be used.
 func WithRegistry(regURL *url.URL) Option {
 	return func(o *serverOptions) {
@@ -231,7 +256,9 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 		opt(opts)
 	}
 
-	rv := &nsmgrServer{}
+	rv := &nsmgrServer{
+		adminServer: opts.adminServer,
+	}
 	var nsRegistry = memory.NewNetworkServiceRegistryServer()
 	if opts.regURL != nil {
 		// Use remote registry
@@ -295,10 +322,18 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 		remoteOrLocalRegistry,
 	)
 	// Construct Endpoint
-	rv.Endpoint = endpoint.NewServer(ctx, tokenGenerator,
+	endpointOptions := []endpoint.Option{
 		endpoint.WithName(opts.name),
 		endpoint.WithAuthorizeServer(opts.authorizeServer),
 		endpoint.WithAuthorizeMonitorConnectionServer(opts.authorizeMonitorConnectionServer),
+	}
+	if opts.revokeServer != nil {
+		endpointOptions = append(endpointOptions, endpoint.WithRevokeServer(opts.revokeServer))
+	}
+	if opts.adminServer != nil {
+		endpointOptions = append(endpointOptions, endpoint.WithAdminServer(opts.adminServer))
+	}
+	rv.Endpoint = endpoint.NewServer(ctx, tokenGenerator, append(endpointOptions,
 		endpoint.WithAdditionalFunctionality(
 			adapters.NewClientToServer(clientinfo.NewClient()),
 			discoverforwarder.NewServer(
@@ -328,7 +363,7 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 				),
 			),
 			sendfd.NewServer()),
-	)
+	)...)
 
 	rv.Registry = registry.NewServer(
 		nsRegistry,
@@ -343,6 +378,9 @@ func (n *nsmgrServer) Register(s *grpc.Server) {
 	networkservice.RegisterMonitorConnectionServer(s, n)
 	registryapi.RegisterNetworkServiceRegistryServer(s, n.Registry.NetworkServiceRegistryServer())
 	registryapi.RegisterNetworkServiceEndpointRegistryServer(s, n.Registry.NetworkServiceEndpointRegistryServer())
+	if n.adminServer != nil {
+		n.adminServer.Register(s)
+	}
 }
 
 var _ Nsmgr = &nsmgrServer{}
@@ -832,3 +832,63 @@ func Test_Expire(t *testing.T) {
 	// Eventually expire will call Unregister
 	require.Len(t, registryapi.ReadNetworkServiceEndpointList(stream), 0)
 }
+
+func Test_ServiceLabelsPolicy(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetNSMgrProxySupplier(nil).
+		SetRegistryProxySupplier(nil).
+		Build()
+
+	nsRegistryClient := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken)
+
+	nsReg, err := nsRegistryClient.Register(ctx, defaultRegistryService("ns"))
+	require.NoError(t, err)
+
+	for _, team := range []string{"a", "b"} {
+		nseReg := &registryapi.NetworkServiceEndpoint{
+			Name:                "nse-" + team,
+			NetworkServiceNames: []string{nsReg.GetName()},
+			NetworkServiceLabels: map[string]*registryapi.NetworkServiceLabels{
+				nsReg.GetName(): {
+					Labels: map[string]string{"team": team},
+				},
+			},
+		}
+		nseClient := registryclient.NewNetworkServiceEndpointRegistryClient(ctx,
+			registryclient.WithClientURL(sandbox.CloneURL(domain.Registry.URL)),
+			registryclient.WithDialOptions(sandbox.DialOptions(sandbox.WithTokenGenerator(sandbox.GenerateTestToken))...),
+		)
+		domain.Nodes[0].NewEndpoint(ctx, nseReg, sandbox.GenerateTestToken, authorize.NewServer(
+			authorize.WithPolicies("../../../tools/opa/sample_policies/service_labels.rego"),
+			authorize.WithConnectionInput(),
+			authorize.WithNSEClient(nseClient),
+		))
+	}
+
+	nsc := domain.Nodes[0].NewClient(ctx, tokenGeneratorFunc("spiffe://example.org/ns/team-a/sa/nsc"))
+
+	request := defaultRequest(nsReg.GetName())
+	request.Connection.NetworkServiceEndpointName = "nse-a"
+
+	conn, err := nsc.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	require.Equal(t, "nse-a", conn.GetNetworkServiceEndpointName())
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+
+	request = defaultRequest(nsReg.GetName())
+	request.Connection.NetworkServiceEndpointName = "nse-b"
+
+	requestCtx, requestCancel := context.WithTimeout(ctx, time.Second)
+	defer requestCancel()
+
+	_, err = nsc.Request(requestCtx, request.Clone())
+	require.Error(t, err)
+}
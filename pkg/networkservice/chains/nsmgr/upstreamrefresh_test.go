@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/chains/client"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/admin"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/monitor"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/upstreamrefresh"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
@@ -259,6 +260,72 @@ func Test_UpstreamRefreshClientDelay(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_UpstreamRefreshClient_AdminReselect(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetNSMgrProxySupplier(nil).
+		SetRegistryProxySupplier(nil).
+		Build()
+
+	nsRegistryClient := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken)
+
+	nsReg, err := nsRegistryClient.Register(ctx, defaultRegistryService("my-service"))
+	require.NoError(t, err)
+
+	allowAll := admin.WithAuthorizeFunc(func(context.Context) error { return nil })
+	admins := map[string]*admin.Server{}
+	counters := map[string]*count.Server{}
+	for _, name := range []string{"final-endpoint1", "final-endpoint2"} {
+		admins[name] = admin.NewServer(allowAll)
+		counters[name] = new(count.Server)
+		_ = domain.Nodes[0].NewEndpoint(
+			ctx,
+			&registry.NetworkServiceEndpoint{
+				Name:                name,
+				NetworkServiceNames: []string{nsReg.Name},
+			},
+			sandbox.GenerateTestToken,
+			admins[name],
+			counters[name],
+		)
+	}
+
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken, client.WithAdditionalFunctionality(upstreamrefresh.NewClient(ctx)))
+
+	reqCtx, reqClose := context.WithTimeout(ctx, time.Second)
+	defer reqClose()
+
+	req := defaultRequest(nsReg.Name)
+	req.Connection.Id = uuid.New().String()
+
+	conn, err := nsc.Request(reqCtx, req)
+	require.NoError(t, err)
+
+	selected := conn.GetNetworkServiceEndpointName()
+	event, err := admins[selected].AdminServer().List(ctx, new(admin.ListRequest))
+	require.NoError(t, err)
+	require.Len(t, event.GetConnections(), 1)
+
+	// The admin of the NSE requests the reselect, the client closes the old path and requests a new one
+	for id := range event.GetConnections() {
+		_, err = admins[selected].AdminServer().Reselect(ctx, &admin.ConnectionID{Id: id})
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool { return counters[selected].Closes() == 1 }, timeout, tick)
+	require.Eventually(t, func() bool {
+		return counters["final-endpoint1"].Requests()+counters["final-endpoint2"].Requests() == 2
+	}, timeout, tick)
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+}
+
 type refreshMTUSenderServer struct {
 	m   *genericsync.Map[string, *networkservice.Connection]
 	mtu uint32
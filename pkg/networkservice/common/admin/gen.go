@@ -0,0 +1,23 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+// Run with protoc v3.14.0. protoc-gen-go is built from the github.com/golang/protobuf version in go.mod, so it is
+// versioned by google.golang.org/protobuf from go.mod too. Its plugins=grpc option emits the gRPC service code.
+//go:generate go build -o protoc-gen-go github.com/golang/protobuf/protoc-gen-go
+//go:generate bash -c "protoc --plugin=protoc-gen-go=./protoc-gen-go -I . -I $(go list -m -f '{{.Dir}}' github.com/networkservicemesh/api)/pkg/api/networkservice admin.proto --go_out=plugins=grpc,paths=source_relative:."
+//go:generate rm protoc-gen-go
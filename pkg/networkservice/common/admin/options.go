@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/ljkiraly/sdk/pkg/tools/spire"
+)
+
+// AuthorizeFunc authorizes the admin API call. Returns an error if the call is not permitted.
+type AuthorizeFunc func(ctx context.Context) error
+
+type options struct {
+	authorizeFunc AuthorizeFunc
+}
+
+// Option is an option pattern for NewServer
+type Option func(*options)
+
+// WithAuthorizeFunc sets the function authorizing the admin API calls. Default: all the calls are denied.
+func WithAuthorizeFunc(authorizeFunc AuthorizeFunc) Option {
+	return func(o *options) {
+		o.authorizeFunc = authorizeFunc
+	}
+}
+
+// WithAllowedSpiffeIDs permits the admin API calls only to the peers with the given SPIFFE IDs
+func WithAllowedSpiffeIDs(spiffeIDs ...spiffeid.ID) Option {
+	allowed := make(map[spiffeid.ID]struct{}, len(spiffeIDs))
+	for _, id := range spiffeIDs {
+		allowed[id] = struct{}{}
+	}
+	return WithAuthorizeFunc(func(ctx context.Context) error {
+		spiffeID, err := spire.PeerSpiffeIDFromContext(ctx)
+		if err != nil {
+			return err
+		}
+		if _, ok := allowed[spiffeID]; !ok {
+			return errors.Errorf("spiffe id %s is not allowed to use the admin API", spiffeID)
+		}
+		return nil
+	})
+}
+
+func denyAll(context.Context) error {
+	return errors.New("admin API is disabled")
+}
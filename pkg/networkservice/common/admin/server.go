@@ -0,0 +1,234 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin provides a NetworkServiceServer chain element keeping the established connections and an optional
+// authenticated gRPC API to list, inspect, close and reselect them and to read their event history.
+//
+// The chain element should be placed after begin, metadata and monitor. The admin API is registered with
+// Server.Register next to the NSMgr or NSE own services.
+//
+// Reselect doesn't reselect the connection locally: it sends a monitor UPDATE event with RESELECT_REQUESTED state, so
+// the client owning the connection reselects it. The client should have the upstreamrefresh chain element.
+package admin
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/history"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/monitor"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/spire"
+)
+
+type entry struct {
+	conn          *networkservice.Connection
+	spiffeID      string
+	factory       begin.EventFactory
+	history       *history.History
+	eventConsumer monitor.EventConsumer
+}
+
+// Server is an admin chain element. Should be placed after begin.
+type Server struct {
+	options
+	connections genericsync.Map[string, *entry]
+}
+
+// NewServer - returns a new admin chain element
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		options: options{
+			authorizeFunc: denyAll,
+		},
+	}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	return s
+}
+
+// Request stores the established connection together with its event factory, the monitor event consumer and the
+// client SPIFFE ID
+func (s *Server) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &entry{
+		conn:    conn.Clone(),
+		factory: begin.FromContext(ctx),
+		history: history.Load(ctx, false),
+	}
+	e.eventConsumer, _ = monitor.LoadEventConsumer(ctx, false)
+	if spiffeID, spiffeErr := spire.PeerSpiffeIDFromContext(ctx); spiffeErr == nil {
+		e.spiffeID = spiffeID.String()
+	} else if prev, ok := s.connections.Load(conn.GetId()); ok {
+		// Refreshes from the event factory have no peer
+		e.spiffeID = prev.spiffeID
+	}
+	s.connections.Store(conn.GetId(), e)
+
+	return conn, nil
+}
+
+// Close forgets the connection
+func (s *Server) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.connections.Delete(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// Register - registers the admin API with *grpc.Server s
+func (s *Server) Register(srv *grpc.Server) {
+	RegisterConnectionAdminServer(srv, s.AdminServer())
+}
+
+// AdminServer - returns the admin API backed by the chain element
+func (s *Server) AdminServer() ConnectionAdminServer {
+	return &adminServer{Server: s}
+}
+
+type adminServer struct {
+	*Server
+}
+
+func (a *adminServer) authorize(ctx context.Context) error {
+	if err := a.authorizeFunc(ctx); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+func (a *adminServer) List(ctx context.Context, request *ListRequest) (*networkservice.ConnectionEvent, error) {
+	if err := a.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	match := func(expected, value string) bool {
+		return expected == "" || expected == value
+	}
+
+	rv := &networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER,
+		Connections: make(map[string]*networkservice.Connection),
+	}
+	a.connections.Range(func(id string, e *entry) bool {
+		if match(request.GetNetworkService(), e.conn.GetNetworkService()) &&
+			match(request.GetNetworkServiceEndpoint(), e.conn.GetNetworkServiceEndpointName()) &&
+			match(request.GetSpiffeId(), e.spiffeID) {
+			rv.Connections[id] = e.conn.Clone()
+		}
+		return true
+	})
+	return rv, nil
+}
+
+func (a *adminServer) Get(ctx context.Context, id *ConnectionID) (*networkservice.Connection, error) {
+	if err := a.authorize(ctx); err != nil {
+		return nil, err
+	}
+	e, err := a.load(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return e.conn.Clone(), nil
+}
+
+func (a *adminServer) Close(ctx context.Context, id *ConnectionID) (*empty.Empty, error) {
+	if err := a.authorize(ctx); err != nil {
+		return nil, err
+	}
+	e, err := a.load(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+	e.history.Add(ctx, history.Evicted, "closed by admin")
+	return wait(ctx, e.factory.Close(begin.CancelContext(ctx)))
+}
+
+func (a *adminServer) Reselect(ctx context.Context, id *ConnectionID) (*empty.Empty, error) {
+	if err := a.authorize(ctx); err != nil {
+		return nil, err
+	}
+	e, err := a.load(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if e.eventConsumer == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "connection %s is not monitored", id.GetId())
+	}
+
+	conn := e.conn.Clone()
+	conn.State = networkservice.State_RESELECT_REQUESTED
+	if err := e.eventConsumer.Send(&networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_UPDATE,
+		Connections: map[string]*networkservice.Connection{conn.GetId(): conn},
+	}); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return new(empty.Empty), nil
+}
+
+func (a *adminServer) History(ctx context.Context, id *ConnectionID) (*HistoryResponse, error) {
+	if err := a.authorize(ctx); err != nil {
+		return nil, err
+	}
+	e, err := a.load(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	rv := new(HistoryResponse)
+	for _, event := range e.history.Events() {
+		rv.Events = append(rv.Events, &HistoryEvent{
+			Time:    timestamppb.New(event.Time),
+			Type:    string(event.Type),
+			Message: event.Message,
+			Count:   uint32(event.Count),
+		})
+	}
+	return rv, nil
+}
+
+func (a *adminServer) load(id string) (*entry, error) {
+	e, ok := a.connections.Load(id)
+	if !ok || e.factory == nil {
+		return nil, status.Errorf(codes.NotFound, "connection %s is not found", id)
+	}
+	return e, nil
+}
+
+func wait(ctx context.Context, ch <-chan error) (*empty.Empty, error) {
+	select {
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case err := <-ch:
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		return new(empty.Empty), nil
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/admin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/history"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/monitor"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+)
+
+func allowAll(context.Context) error {
+	return nil
+}
+
+func requestConnections(ctx context.Context, t *testing.T, server networkservice.NetworkServiceServer) {
+	for _, conn := range []*networkservice.Connection{
+		{Id: "conn-1", NetworkService: "ns-1", NetworkServiceEndpointName: "nse-1"},
+		{Id: "conn-2", NetworkService: "ns-1", NetworkServiceEndpointName: "nse-2"},
+		{Id: "conn-3", NetworkService: "ns-2", NetworkServiceEndpointName: "nse-2"},
+	} {
+		_, err := server.Request(ctx, &networkservice.NetworkServiceRequest{Connection: conn})
+		require.NoError(t, err)
+	}
+}
+
+func TestAdminServer_ListGetClose(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	counter := new(count.Server)
+	adminServer := admin.NewServer(admin.WithAuthorizeFunc(allowAll))
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		adminServer,
+		counter,
+	)
+	requestConnections(ctx, t, server)
+
+	api := adminServer.AdminServer()
+
+	event, err := api.List(ctx, new(admin.ListRequest))
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, event.GetType())
+	require.Len(t, event.GetConnections(), 3)
+
+	event, err = api.List(ctx, &admin.ListRequest{NetworkService: "ns-1"})
+	require.NoError(t, err)
+	require.Len(t, event.GetConnections(), 2)
+
+	event, err = api.List(ctx, &admin.ListRequest{
+		NetworkService:         "ns-1",
+		NetworkServiceEndpoint: "nse-2",
+	})
+	require.NoError(t, err)
+	require.Len(t, event.GetConnections(), 1)
+	require.Contains(t, event.GetConnections(), "conn-2")
+
+	conn, err := api.Get(ctx, &admin.ConnectionID{Id: "conn-3"})
+	require.NoError(t, err)
+	require.Equal(t, "nse-2", conn.GetNetworkServiceEndpointName())
+
+	_, err = api.Close(ctx, &admin.ConnectionID{Id: "conn-3"})
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.Closes())
+
+	_, err = api.Get(ctx, &admin.ConnectionID{Id: "conn-3"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestAdminServer_Reselect(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	counter := new(count.Server)
+	adminServer := admin.NewServer(admin.WithAuthorizeFunc(allowAll))
+	var monitorServer networkservice.MonitorConnectionServer
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		history.NewServer(),
+		monitor.NewServer(ctx, &monitorServer),
+		adminServer,
+		counter,
+	)
+
+	monitorClient, err := adapters.NewMonitorServerToClient(monitorServer).MonitorConnections(ctx, &networkservice.MonitorScopeSelector{
+		PathSegments: []*networkservice.PathSegment{{Name: "nsc"}},
+	})
+	require.NoError(t, err)
+	event, err := monitorClient.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, event.GetType())
+
+	_, err = server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:                         "conn-1",
+			NetworkServiceEndpointName: "nse-1",
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: "nsc", Id: "conn-1"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	event, err = monitorClient.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+
+	// Reselect is requested from the client with the monitor event, the connection is kept till then
+	_, err = adminServer.AdminServer().Reselect(ctx, &admin.ConnectionID{Id: "conn-1"})
+	require.NoError(t, err)
+
+	event, err = monitorClient.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+	require.Equal(t, networkservice.State_RESELECT_REQUESTED, event.GetConnections()["conn-1"].GetState())
+
+	require.Equal(t, 1, counter.Requests())
+	require.Equal(t, 0, counter.Closes())
+
+	conn, err := adminServer.AdminServer().Get(ctx, &admin.ConnectionID{Id: "conn-1"})
+	require.NoError(t, err)
+	require.Equal(t, "nse-1", conn.GetNetworkServiceEndpointName())
+
+	events, err := adminServer.AdminServer().History(ctx, &admin.ConnectionID{Id: "conn-1"})
+	require.NoError(t, err)
+	require.Len(t, events.GetEvents(), 1)
+	require.Equal(t, string(history.Requested), events.GetEvents()[0].GetType())
+}
+
+func TestAdminServer_ReselectNotMonitored(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	adminServer := admin.NewServer(admin.WithAuthorizeFunc(allowAll))
+	requestConnections(ctx, t, chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		adminServer,
+	))
+
+	_, err := adminServer.AdminServer().Reselect(ctx, &admin.ConnectionID{Id: "conn-1"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestAdminServer_Denied(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	for _, adminServer := range []*admin.Server{
+		admin.NewServer(),
+		admin.NewServer(admin.WithAuthorizeFunc(func(context.Context) error {
+			return errors.New("denied")
+		})),
+	} {
+		api := adminServer.AdminServer()
+
+		_, err := api.List(ctx, new(admin.ListRequest))
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		_, err = api.Close(ctx, &admin.ConnectionID{Id: "conn-1"})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	}
+}
+
+func TestAdminServer_GRPC(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	adminServer := admin.NewServer(admin.WithAuthorizeFunc(allowAll))
+	requestConnections(ctx, t, chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		adminServer,
+	))
+
+	s := grpc.NewServer()
+	adminServer.Register(s)
+
+	var serverURL url.URL
+	require.Len(t, grpcutils.ListenAndServe(ctx, &serverURL, s), 0)
+
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(&serverURL),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	client := admin.NewConnectionAdminClient(cc)
+
+	event, err := client.List(ctx, &admin.ListRequest{NetworkServiceEndpoint: "nse-2"})
+	require.NoError(t, err)
+	require.Len(t, event.GetConnections(), 2)
+
+	_, err = client.Close(ctx, &admin.ConnectionID{Id: "conn-2"})
+	require.NoError(t, err)
+
+	_, err = client.Get(ctx, &admin.ConnectionID{Id: "conn-2"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -38,6 +38,7 @@ import (
 type authorizeClient struct {
 	policies   policiesList
 	serverPeer atomic.Value
+	inputOptions
 }
 
 // NewClient - returns a new authorization networkservicemesh.NetworkServiceClient
@@ -63,7 +64,8 @@ func NewClient(opts ...Option) networkservice.NetworkServiceClient {
 	}
 
 	var result = &authorizeClient{
-		policies: policyList,
+		policies:     policyList,
+		inputOptions: o.inputOptions,
 	}
 	return result
 }
@@ -84,7 +86,7 @@ func (a *authorizeClient) Request(ctx context.Context, request *networkservice.N
 		ctx = peer.NewContext(ctx, &p)
 	}
 
-	if err = a.policies.check(ctx, conn.GetPath()); err != nil {
+	if err = a.policies.check(ctx, policyInput(ctx, &a.inputOptions, conn, conn.GetPath())); err != nil {
 		if !load(ctx, metadata.IsClient(a)) {
 			closeCtx, cancelClose := postponeCtxFunc()
 			defer cancelClose()
@@ -107,7 +109,7 @@ func (a *authorizeClient) Close(ctx context.Context, conn *networkservice.Connec
 	}
 	del(ctx, metadata.IsClient(a))
 
-	if err := a.policies.check(ctx, conn.GetPath()); err != nil {
+	if err := a.policies.check(ctx, policyInput(ctx, &a.inputOptions, conn, conn.GetPath())); err != nil {
 		return nil, err
 	}
 
@@ -22,11 +22,88 @@ import (
 	"context"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
 	"github.com/pkg/errors"
 
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/discover"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
 )
 
+// NetworkServiceOpaInput represents input for policies in authorizeServer and authorizeClient created WithConnectionInput.
+// It is the checked Path extended with the connection fields, see opa package README for the full schema.
+type NetworkServiceOpaInput struct {
+	*networkservice.Path
+	NetworkService         string            `json:"network_service"`
+	Labels                 map[string]string `json:"labels"`
+	NetworkServiceEndpoint *EndpointOpaInput `json:"network_service_endpoint"`
+	MechanismType          string            `json:"mechanism_type"`
+}
+
+// EndpointOpaInput represents the selected NSE in NetworkServiceOpaInput
+type EndpointOpaInput struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+}
+
+// newNetworkServiceOpaInput creates input for the path of conn. Labels of the selected NSE are taken from the discover
+// candidates if they are presented in ctx, otherwise the NSE is found by name with nseClient.
+func newNetworkServiceOpaInput(ctx context.Context, nseClient registry.NetworkServiceEndpointRegistryClient, conn *networkservice.Connection, path *networkservice.Path) *NetworkServiceOpaInput {
+	input := &NetworkServiceOpaInput{
+		Path:           path,
+		NetworkService: conn.GetNetworkService(),
+		Labels:         conn.GetLabels(),
+		NetworkServiceEndpoint: &EndpointOpaInput{
+			Name: conn.GetNetworkServiceEndpointName(),
+		},
+		MechanismType: conn.GetMechanism().GetType(),
+	}
+	if nse := selectedEndpoint(ctx, nseClient, input.NetworkServiceEndpoint.Name); nse != nil {
+		input.NetworkServiceEndpoint.Labels = nse.GetNetworkServiceLabels()[conn.GetNetworkService()].GetLabels()
+	}
+	return input
+}
+
+// selectedEndpoint returns the NSE with name from the discover candidates or from the registry
+func selectedEndpoint(ctx context.Context, nseClient registry.NetworkServiceEndpointRegistryClient, name string) *registry.NetworkServiceEndpoint {
+	if name == "" {
+		return nil
+	}
+	if candidates := discover.Candidates(ctx); candidates != nil {
+		for _, nse := range candidates.Endpoints {
+			if nse.GetName() == name {
+				return nse
+			}
+		}
+	}
+	if nseClient == nil {
+		return nil
+	}
+
+	stream, err := nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			Name: name,
+		},
+	})
+	if err != nil {
+		log.FromContext(ctx).Warnf("failed to find nse %s: %v", name, err.Error())
+		return nil
+	}
+	for _, nse := range registry.ReadNetworkServiceEndpointList(stream) {
+		if nse.GetName() == name {
+			return nse
+		}
+	}
+	return nil
+}
+
+// policyInput returns the checked path, or NetworkServiceOpaInput for it if connectionInput is enabled
+func policyInput(ctx context.Context, o *inputOptions, conn *networkservice.Connection, path *networkservice.Path) interface{} {
+	if !o.connectionInput {
+		return path
+	}
+	return newNetworkServiceOpaInput(ctx, o.nseClient, conn, path)
+}
+
 // Policy represents authorization policy for network service.
 type Policy interface {
 	// Name returns policy name
@@ -37,7 +114,7 @@ type Policy interface {
 
 type policiesList []Policy
 
-func (l *policiesList) check(ctx context.Context, p *networkservice.Path) error {
+func (l *policiesList) check(ctx context.Context, input interface{}) error {
 	if l == nil {
 		return nil
 	}
@@ -45,7 +122,7 @@ func (l *policiesList) check(ctx context.Context, p *networkservice.Path) error
 		if policy == nil {
 			continue
 		}
-		if err := policy.Check(ctx, p); err != nil {
+		if err := policy.Check(ctx, input); err != nil {
 			log.FromContext(ctx).Errorf("policy failed: %v", policy.Name())
 			return errors.Wrap(err, "networkservice: an error occurred during authorization policy check")
 		}
@@ -21,11 +21,23 @@ package authorize
 import (
 	"github.com/edwarnicke/genericsync"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
 )
 
 type options struct {
 	policyPaths           []string
 	spiffeIDConnectionMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]
+	revocationList        *revocation.List
+	inputOptions
+}
+
+// inputOptions configure the policy input
+type inputOptions struct {
+	connectionInput bool
+	nseClient       registry.NetworkServiceEndpointRegistryClient
 }
 
 // Option is authorization option for network service server
@@ -50,3 +62,28 @@ func WithSpiffeIDConnectionMap(s *genericsync.Map[spiffeid.ID, *genericsync.Map[
 		o.spiffeIDConnectionMap = s
 	}
 }
+
+// WithRevocationList sets revocation list to be passed to the policies input
+func WithRevocationList(l *revocation.List) Option {
+	return func(o *options) {
+		o.revocationList = l
+	}
+}
+
+// WithConnectionInput makes the policies get NetworkServiceOpaInput with the connection fields instead of
+// *networkservice.Path. The path fields are kept in the same place, so the rego policies work with both inputs,
+// but the custom Go policies should handle NetworkServiceOpaInput.
+func WithConnectionInput() Option {
+	return func(o *options) {
+		o.connectionInput = true
+	}
+}
+
+// WithNSEClient sets the registry client used with WithConnectionInput to find the selected NSE by name, if it is not
+// discovered in the chain. Without it the NSE labels are presented only in the chains having discover before
+// authorize.
+func WithNSEClient(nseClient registry.NetworkServiceEndpointRegistryClient) Option {
+	return func(o *options) {
+		o.nseClient = nseClient
+	}
+}
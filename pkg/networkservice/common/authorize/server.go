@@ -32,12 +32,15 @@ import (
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/opa"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
 	"github.com/ljkiraly/sdk/pkg/tools/spire"
 )
 
 type authorizeServer struct {
 	policies              policiesList
 	spiffeIDConnectionMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]
+	revocationList        *revocation.List
+	inputOptions
 }
 
 // NewServer - returns a new authorization networkservicemesh.NetworkServiceServers
@@ -66,6 +69,8 @@ func NewServer(opts ...Option) networkservice.NetworkServiceServer {
 	var s = &authorizeServer{
 		policies:              policyList,
 		spiffeIDConnectionMap: o.spiffeIDConnectionMap,
+		revocationList:        o.revocationList,
+		inputOptions:          o.inputOptions,
 	}
 	return s
 }
@@ -78,7 +83,7 @@ func (a *authorizeServer) Request(ctx context.Context, request *networkservice.N
 		PathSegments: conn.GetPath().GetPathSegments()[:index+1],
 	}
 	if _, ok := peer.FromContext(ctx); ok {
-		if err := a.policies.check(ctx, leftSide); err != nil {
+		if err := a.policies.check(a.withRevocationList(ctx), policyInput(ctx, &a.inputOptions, conn, leftSide)); err != nil {
 			return nil, err
 		}
 	}
@@ -131,9 +136,16 @@ func (a *authorizeServer) Close(ctx context.Context, conn *networkservice.Connec
 	}
 
 	if p, ok := peer.FromContext(ctx); ok && p != nil && *p != (peer.Peer{}) {
-		if err := a.policies.check(ctx, leftSide); err != nil {
+		if err := a.policies.check(a.withRevocationList(ctx), policyInput(ctx, &a.inputOptions, conn, leftSide)); err != nil {
 			return nil, err
 		}
 	}
 	return next.Server(ctx).Close(ctx, conn)
 }
+
+func (a *authorizeServer) withRevocationList(ctx context.Context) context.Context {
+	if a.revocationList == nil {
+		return ctx
+	}
+	return revocation.WithList(ctx, a.revocationList)
+}
@@ -193,6 +193,54 @@ func TestAuthzEndpoint(t *testing.T) {
 	}
 }
 
+func TestAuthorize_ConnectionFieldsInput(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	dir := t.TempDir()
+	policyPath := filepath.Clean(path.Join(dir, "policy.rego"))
+	err := os.WriteFile(policyPath, []byte(`
+package test
+
+default valid = false
+
+valid {
+	input.input_version == "v1"
+	input.network_service == "ns"
+	input.labels.app == "nsc"
+	input.network_service_endpoint.name == "nse"
+	input.mechanism_type == "KERNEL"
+}
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			NetworkService:             "ns",
+			NetworkServiceEndpointName: "nse",
+			Labels:                     map[string]string{"app": "nsc"},
+			Mechanism:                  &networkservice.Mechanism{Type: "KERNEL"},
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{}},
+			},
+		},
+	}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.IPAddr{}})
+
+	// The connection fields are opt-in, the path is checked by default
+	_, err = authorize.NewServer(authorize.WithPolicies(policyPath)).Request(ctx, request)
+	require.Error(t, err)
+
+	srv := authorize.NewServer(authorize.WithPolicies(policyPath), authorize.WithConnectionInput())
+
+	_, err = srv.Request(ctx, request)
+	require.NoError(t, err)
+
+	request.GetConnection().NetworkService = "other-ns"
+	_, err = srv.Request(ctx, request)
+	require.Error(t, err)
+}
+
 func TestAuthorize_EmptySpiffeIDConnectionMapOnClose(t *testing.T) {
 	t.Cleanup(func() { goleak.VerifyNone(t) })
 
@@ -58,16 +58,23 @@ func (b *beginClient) Request(ctx context.Context, request *networkservice.Netwo
 	if fromContext(ctx) != nil {
 		return next.Client(ctx).Request(ctx, request, opts...)
 	}
-	eventFactoryClient, _ := b.LoadOrStore(request.GetConnection().GetId(),
-		newEventFactoryClient(
-			ctx,
-			func() {
-				b.Delete(request.GetRequestConnection().GetId())
-			},
-			b.reselectFunc,
-			opts...,
-		),
+	ids := []string{request.GetConnection().GetId()}
+	newEventFactory := newEventFactoryClient(
+		ctx,
+		func() {
+			for _, id := range ids {
+				b.Delete(id)
+			}
+		},
+		b.reselectFunc,
+		opts...,
 	)
+	// The connection ID is changed on make-before-break reselect, keep the EventFactory available by both IDs
+	newEventFactory.idChangedFunc = func(id string) {
+		ids = append(ids, id)
+		b.Store(id, newEventFactory)
+	}
+	eventFactoryClient, _ := b.LoadOrStore(ids[0], newEventFactory)
 	<-eventFactoryClient.executor.AsyncExec(func() {
 		// If the eventFactory has changed, usually because the connection has been Closed and re-established
 		// go back to the beginning and try again.
@@ -30,6 +30,12 @@ func withEventFactory(parent context.Context, eventFactory EventFactory) context
 	return ctx
 }
 
+// WithEventFactory - returns a new context with eventFactory replacing the one set by begin. Allows chain elements
+// managing their own connections below begin to receive the events for them.
+func WithEventFactory(parent context.Context, eventFactory EventFactory) context.Context {
+	return context.WithValue(parent, key{}, eventFactory)
+}
+
 // FromContext - returns EventFactory from context
 func FromContext(ctx context.Context) EventFactory {
 	value := fromContext(ctx)
@@ -20,11 +20,15 @@ import (
 	"context"
 
 	"github.com/edwarnicke/serialize"
+	"github.com/google/uuid"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
 
+	"github.com/ljkiraly/sdk/pkg/tools/exclusionutils"
 	"github.com/ljkiraly/sdk/pkg/tools/extend"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
 	"github.com/ljkiraly/sdk/pkg/tools/postpone"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
@@ -76,6 +80,7 @@ type eventFactoryClient struct {
 	opts               []grpc.CallOption
 	client             networkservice.NetworkServiceClient
 	afterCloseFunc     func()
+	idChangedFunc      func(id string)
 	reselectFunc       ReselectFunc
 }
 
@@ -121,6 +126,10 @@ func (f *eventFactoryClient) Request(opts ...Option) <-chan error {
 		select {
 		case <-o.cancelCtx.Done():
 		default:
+			if o.reselect && o.makeBeforeBreak {
+				ch <- f.requestMakeBeforeBreak(o.livenessCheck)
+				return
+			}
 			request := f.request.Clone()
 			if o.reselect {
 				ctx, cancel := f.ctxFunc()
@@ -141,6 +150,57 @@ func (f *eventFactoryClient) Request(opts ...Option) <-chan error {
 	return ch
 }
 
+// requestMakeBeforeBreak - reselects the connection under a new path segment ID and closes the old one only after the
+// new one is established and alive. Both connections exist in the meantime, so both are reported via monitor events.
+// The previous NSE is excluded from the selection, so the new connection goes to a different one.
+func (f *eventFactoryClient) requestMakeBeforeBreak(livenessCheck LivenessCheck) error {
+	prevConn := f.request.GetConnection().Clone()
+
+	request := f.request.Clone()
+	f.reselectFunc(request)
+	renewPathSegment(request.GetConnection())
+	if request.GetConnection().GetNetworkServiceEndpointName() == "" && prevConn.GetNetworkServiceEndpointName() != "" {
+		exclusionutils.Exclude(request.GetConnection(), prevConn.GetNetworkServiceEndpointName())
+	}
+
+	ctx, cancel := f.ctxFunc()
+	defer cancel()
+
+	conn, err := f.client.Request(ctx, request, f.opts...)
+	if err != nil {
+		return err
+	}
+	if !livenessCheck(ctx, conn) {
+		_, _ = f.client.Close(ctx, conn, f.opts...)
+		return errors.Errorf("reselected connection %s is not alive", conn.GetId())
+	}
+
+	exclusionutils.Clear(conn)
+	f.request.Connection = conn
+	f.request.Connection.State = networkservice.State_UP
+	if f.idChangedFunc != nil && conn.GetId() != prevConn.GetId() {
+		f.idChangedFunc(conn.GetId())
+	}
+
+	if _, err := f.client.Close(ctx, prevConn, f.opts...); err != nil {
+		log.FromContext(ctx).Warnf("failed to close previous connection %s: %s", prevConn.GetId(), err.Error())
+	}
+	return nil
+}
+
+// renewPathSegment - assigns a new ID to the current path segment and drops all the next ones, so the next hops
+// treat the request as a new connection
+func renewPathSegment(conn *networkservice.Connection) {
+	conn.Id = uuid.New().String()
+
+	path := conn.GetPath()
+	if int(path.GetIndex()) >= len(path.GetPathSegments()) {
+		return
+	}
+	path.PathSegments = path.GetPathSegments()[:path.GetIndex()+1]
+	path.GetPathSegments()[path.GetIndex()].Id = conn.GetId()
+}
+
 func (f *eventFactoryClient) Close(opts ...Option) <-chan error {
 	o := &option{
 		cancelCtx: context.Background(),
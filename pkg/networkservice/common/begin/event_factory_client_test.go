@@ -18,7 +18,7 @@ package begin_test
 
 import (
 	"context"
-
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -39,6 +39,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
 	"github.com/ljkiraly/sdk/pkg/tools/clock"
 	"github.com/ljkiraly/sdk/pkg/tools/clockmock"
+	"github.com/ljkiraly/sdk/pkg/tools/exclusionutils"
 )
 
 // This test reproduces the situation when refresh changes the eventFactory context
@@ -218,6 +219,168 @@ func TestContextCancellationOnReselect(t *testing.T) {
 	}, time.Second, time.Millisecond*100)
 }
 
+func mbbTestRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "1",
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "nsc", Id: "1"},
+					{Name: "nsmgr", Id: "nsmgr-1"},
+				},
+			},
+			NetworkServiceEndpointName: "nse-1",
+		},
+	}
+}
+
+// This test checks that make-before-break reselect closes the old connection only after the new one is established
+func TestMakeBeforeBreakReselect_Client(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	eventFactoryCl := &eventFactoryClient{}
+	recorder := &recordClient{}
+	client := chain.NewNetworkServiceClient(
+		begin.NewClient(),
+		eventFactoryCl,
+		recorder,
+	)
+
+	conn, err := client.Request(ctx, mbbTestRequest())
+	require.NoError(t, err)
+
+	var checkedID string
+	err = <-begin.FromContext(eventFactoryCl.ctx).Request(begin.WithReselect(), begin.WithMakeBeforeBreak(
+		func(_ context.Context, conn *networkservice.Connection) bool {
+			checkedID = conn.GetId()
+			return true
+		}))
+	require.NoError(t, err)
+
+	events := recorder.getEvents()
+	require.Len(t, events, 3)
+	newID := checkedID
+	require.NotEqual(t, conn.GetId(), newID)
+	require.Equal(t, "request "+newID, events[1])
+	require.Equal(t, "close 1", events[2])
+
+	// The new connection doesn't carry the next hops of the old one
+	newConn := recorder.lastConn()
+	require.Len(t, newConn.GetPath().GetPathSegments(), 1)
+	require.Equal(t, newID, newConn.GetPath().GetPathSegments()[0].GetId())
+	require.Empty(t, newConn.GetNetworkServiceEndpointName())
+	require.Equal(t, "nse-1", exclusionutils.Excluded(newConn))
+
+	// The EventFactory is available by both the old and the new connection IDs
+	conn, err = client.Request(ctx, &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	require.Equal(t, newID, conn.GetId())
+	require.Equal(t, "request "+newID, recorder.getEvents()[3])
+
+	_, err = client.Close(ctx, newConn)
+	require.NoError(t, err)
+	require.Equal(t, "close "+newID, recorder.getEvents()[4])
+}
+
+// This test checks that make-before-break reselect keeps the old connection if the new one is not alive
+func TestMakeBeforeBreakReselect_NotAlive_Client(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	eventFactoryCl := &eventFactoryClient{}
+	recorder := &recordClient{}
+	client := chain.NewNetworkServiceClient(
+		begin.NewClient(),
+		eventFactoryCl,
+		recorder,
+	)
+
+	conn, err := client.Request(ctx, mbbTestRequest())
+	require.NoError(t, err)
+
+	err = <-begin.FromContext(eventFactoryCl.ctx).Request(begin.WithReselect(), begin.WithMakeBeforeBreak(
+		func(context.Context, *networkservice.Connection) bool {
+			return false
+		}))
+	require.Error(t, err)
+
+	events := recorder.getEvents()
+	require.Len(t, events, 3)
+	require.NotEqual(t, "close 1", events[2])
+
+	_, err = client.Close(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, "close 1", recorder.getEvents()[3])
+}
+
+// This test checks that make-before-break is not used without the liveness check
+func TestMakeBeforeBreakReselect_NoLivenessCheck_Client(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	eventFactoryCl := &eventFactoryClient{}
+	recorder := &recordClient{}
+	client := chain.NewNetworkServiceClient(
+		begin.NewClient(),
+		eventFactoryCl,
+		recorder,
+	)
+
+	conn, err := client.Request(ctx, mbbTestRequest())
+	require.NoError(t, err)
+
+	err = <-begin.FromContext(eventFactoryCl.ctx).Request(begin.WithReselect(), begin.WithMakeBeforeBreak(nil))
+	require.NoError(t, err)
+
+	events := recorder.getEvents()
+	require.Len(t, events, 3)
+	require.Equal(t, "close 1", events[1])
+	require.Equal(t, "request 1", events[2])
+
+	_, err = client.Close(ctx, conn)
+	require.NoError(t, err)
+}
+
+type recordClient struct {
+	mu     sync.Mutex
+	events []string
+	conn   *networkservice.Connection
+}
+
+func (r *recordClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	r.mu.Lock()
+	r.events = append(r.events, "request "+request.GetConnection().GetId())
+	r.conn = request.GetConnection().Clone()
+	r.mu.Unlock()
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (r *recordClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	r.mu.Lock()
+	r.events = append(r.events, "close "+conn.GetId())
+	r.mu.Unlock()
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func (r *recordClient) getEvents() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+func (r *recordClient) lastConn() *networkservice.Connection {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.conn.Clone()
+}
+
 type eventFactoryClient struct {
 	ctx context.Context
 }
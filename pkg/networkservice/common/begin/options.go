@@ -18,11 +18,18 @@ package begin
 
 import (
 	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
 )
 
+// LivenessCheck - function that returns true if conn is 'live' and false otherwise
+type LivenessCheck func(ctx context.Context, conn *networkservice.Connection) bool
+
 type option struct {
-	cancelCtx context.Context
-	reselect  bool
+	cancelCtx       context.Context
+	reselect        bool
+	makeBeforeBreak bool
+	livenessCheck   LivenessCheck
 }
 
 type clientOption struct {
@@ -49,6 +56,17 @@ func WithReselect() Option {
 	}
 }
 
+// WithMakeBeforeBreak - optionally keep the old connection until the reselected one is established. Used together with
+// WithReselect: the new connection is requested under a new path segment ID to a different NSE, checked with
+// livenessCheck and only then the old connection is closed. The new connection can't be confirmed without
+// livenessCheck, so the option has no effect if it is nil. Client only.
+func WithMakeBeforeBreak(livenessCheck LivenessCheck) Option {
+	return func(o *option) {
+		o.makeBeforeBreak = livenessCheck != nil
+		o.livenessCheck = livenessCheck
+	}
+}
+
 // WithReselectFunc - sets a function for changing request parameters on reselect
 func WithReselectFunc(reselectFunc ReselectFunc) ClientOption {
 	return func(o *clientOption) {
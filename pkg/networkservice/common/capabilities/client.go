@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capabilities
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+)
+
+type capabilitiesClient struct {
+	*options
+}
+
+// NewClient - returns a client chain element publishing the client side capabilities in the mechanism preferences
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	return &capabilitiesClient{
+		options: newOptions(opts...),
+	}
+}
+
+func (c *capabilitiesClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	for _, mechanism := range append(request.GetMechanismPreferences(), request.GetConnection().GetMechanism()) {
+		if capabilities, ok := c.capabilitiesFor(mechanism.GetType()); ok && mechanism != nil {
+			publish(mechanism, capabilities, SrcMaxMTU, SrcFeatures)
+		}
+	}
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *capabilitiesClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capabilities
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/srv6"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
+)
+
+// Mechanism parameters
+const (
+	// SrcMaxMTU - max MTU supported by the client side
+	SrcMaxMTU = "src_max_mtu"
+	// DstMaxMTU - max MTU supported by the server side
+	DstMaxMTU = "dst_max_mtu"
+	// SrcFeatures - comma separated features supported by the client side
+	SrcFeatures = "src_features"
+	// DstFeatures - comma separated features supported by the server side
+	DstFeatures = "dst_features"
+	// Features - comma separated negotiated features supported by both sides
+	Features = "features"
+	// PayloadMTU - negotiated MTU of the payload, accounting for the mechanism tunnel overhead. It is kept apart from
+	// common.MTU the forwarders set for the tunnel interface.
+	PayloadMTU = "payload_mtu"
+)
+
+// Features
+const (
+	// FeatureChecksumOffload - checksum offload
+	FeatureChecksumOffload = "csum_offload"
+	// FeatureGSO - generic segmentation offload
+	FeatureGSO = "gso"
+	// FeatureEncryption - encryption
+	FeatureEncryption = "encryption"
+)
+
+// Capabilities of one side for a mechanism type
+type Capabilities struct {
+	// MaxMTU - max MTU of the underlying link, 0 means unknown
+	MaxMTU uint32
+	// Features - supported features
+	Features []string
+}
+
+// defaultOverheads are the worst case (IPv6 underlay) tunnel overheads per mechanism type
+var defaultOverheads = map[string]uint32{
+	vxlan.MECHANISM:     70,
+	wireguard.MECHANISM: 80,
+	ipsec.MECHANISM:     104,
+	srv6.MECHANISM:      78,
+}
+
+type options struct {
+	capabilities        map[string]*Capabilities
+	defaultCapabilities *Capabilities
+	requiredFeatures    []string
+	preferredFeatures   []string
+	overheads           map[string]uint32
+}
+
+// Option is an option pattern for capabilities client/server
+type Option func(o *options)
+
+// WithCapabilities sets capabilities for the mechanism type
+func WithCapabilities(mechanismType string, capabilities Capabilities) Option {
+	return func(o *options) {
+		o.capabilities[mechanismType] = &capabilities
+	}
+}
+
+// WithDefaultCapabilities sets capabilities for the mechanism types without capabilities set by WithCapabilities
+func WithDefaultCapabilities(capabilities Capabilities) Option {
+	return func(o *options) {
+		o.defaultCapabilities = &capabilities
+	}
+}
+
+// WithRequiredFeatures makes server to drop mechanisms not supporting the features on both sides
+func WithRequiredFeatures(features ...string) Option {
+	return func(o *options) {
+		o.requiredFeatures = features
+	}
+}
+
+// WithPreferredFeatures makes server to move mechanisms supporting more of the features on both sides ahead, mechanisms
+// supporting the same number of them keep the client order
+func WithPreferredFeatures(features ...string) Option {
+	return func(o *options) {
+		o.preferredFeatures = features
+	}
+}
+
+// WithOverhead overrides the tunnel overhead of the mechanism type
+func WithOverhead(mechanismType string, overhead uint32) Option {
+	return func(o *options) {
+		o.overheads[mechanismType] = overhead
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		capabilities: make(map[string]*Capabilities),
+		overheads:    make(map[string]uint32),
+	}
+	for mechanismType, overhead := range defaultOverheads {
+		o.overheads[mechanismType] = overhead
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *options) capabilitiesFor(mechanismType string) (*Capabilities, bool) {
+	if c, ok := o.capabilities[mechanismType]; ok {
+		return c, true
+	}
+	return o.defaultCapabilities, o.defaultCapabilities != nil
+}
+
+// publish sets the capabilities to the mechanism parameters
+func publish(mechanism *networkservice.Mechanism, capabilities *Capabilities, maxMTUKey, featuresKey string) {
+	if mechanism.GetParameters() == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	if capabilities.MaxMTU != 0 {
+		mechanism.GetParameters()[maxMTUKey] = strconv.FormatUint(uint64(capabilities.MaxMTU), 10)
+	} else {
+		delete(mechanism.GetParameters(), maxMTUKey)
+	}
+	mechanism.GetParameters()[featuresKey] = strings.Join(capabilities.Features, ",")
+}
+
+// read gets the capabilities from the mechanism parameters
+func read(mechanism *networkservice.Mechanism, maxMTUKey, featuresKey string) *Capabilities {
+	capabilities := new(Capabilities)
+	if maxMTU, err := strconv.ParseUint(mechanism.GetParameters()[maxMTUKey], 10, 32); err == nil {
+		capabilities.MaxMTU = uint32(maxMTU)
+	}
+	for _, feature := range strings.Split(mechanism.GetParameters()[featuresKey], ",") {
+		if feature = strings.TrimSpace(feature); feature != "" {
+			capabilities.Features = append(capabilities.Features, feature)
+		}
+	}
+	return capabilities
+}
+
+type result struct {
+	mtu      uint32
+	features []string
+}
+
+// negotiate returns the best parameter set supported by both sides
+func (o *options) negotiate(mechanismType string, src, dst *Capabilities) *result {
+	r := new(result)
+
+	linkMTU := src.MaxMTU
+	if linkMTU == 0 || dst.MaxMTU != 0 && dst.MaxMTU < linkMTU {
+		linkMTU = dst.MaxMTU
+	}
+	if overhead := o.overheads[mechanismType]; linkMTU > overhead {
+		r.mtu = linkMTU - overhead
+	}
+
+	for _, feature := range src.Features {
+		if contains(dst.Features, feature) {
+			r.features = append(r.features, feature)
+		}
+	}
+	return r
+}
+
+func (r *result) supports(features []string) bool {
+	for _, feature := range features {
+		if !contains(r.features, feature) {
+			return false
+		}
+	}
+	return true
+}
+
+// rank returns the number of the features supported by r
+func (r *result) rank(features []string) int {
+	var count int
+	for _, feature := range features {
+		if contains(r.features, feature) {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *result) store(mechanism *networkservice.Mechanism) {
+	if r.mtu != 0 {
+		mechanism.GetParameters()[PayloadMTU] = strconv.FormatUint(uint64(r.mtu), 10)
+	} else {
+		delete(mechanism.GetParameters(), PayloadMTU)
+	}
+	mechanism.GetParameters()[Features] = strings.Join(r.features, ",")
+}
+
+func contains(features []string, feature string) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+type candidate struct {
+	mechanism *networkservice.Mechanism
+	result    *result
+}
+
+// sortCandidates moves the candidates supporting more preferred features ahead, keeping the client order otherwise
+func sortCandidates(candidates []*candidate, preferredFeatures []string) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].result.rank(preferredFeatures) > candidates[j].result.rank(preferredFeatures)
+	})
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package capabilities provides chain elements negotiating mechanisms by capabilities both ends publish in the
+// mechanism parameters: max MTU and feature flags (checksum offload, GSO, encryption). The server drops the mechanisms
+// missing the required features, moves the ones supporting more preferred features ahead of the client order and
+// stores the negotiated result (payload MTU accounting for the tunnel overhead and mutual features) in the mechanism
+// parameters.
+package capabilities
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capabilities
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+type capabilitiesServer struct {
+	*options
+}
+
+// NewServer - returns a server chain element negotiating the mechanism parameters with the client capabilities.
+// Mechanism preferences keep the client order, except mechanisms supporting more preferred features go first, so it
+// should be placed before mechanisms.NewServer. Mechanism types without server capabilities are kept at the tail.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	return &capabilitiesServer{
+		options: newOptions(opts...),
+	}
+}
+
+func (s *capabilitiesServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if mechanism := request.GetConnection().GetMechanism(); mechanism != nil {
+		// Mechanism is already selected, just renegotiate its parameters
+		c := s.candidate(mechanism)
+		if !s.acceptable(c) {
+			return nil, status.Errorf(codes.FailedPrecondition, "mechanism %s doesn't support required features %v", mechanism.GetType(), s.requiredFeatures)
+		}
+		if c != nil {
+			c.result.store(mechanism)
+		}
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	var candidates []*candidate
+	var rest []*networkservice.Mechanism
+	for _, mechanism := range request.GetMechanismPreferences() {
+		c := s.candidate(mechanism)
+		switch {
+		case !s.acceptable(c):
+			log.FromContext(ctx).WithField("capabilitiesServer", "request").
+				Debugf("mechanism %s doesn't support required features %v", mechanism.GetType(), s.requiredFeatures)
+		case c == nil:
+			rest = append(rest, mechanism)
+		default:
+			c.result.store(mechanism)
+			candidates = append(candidates, c)
+		}
+	}
+	if len(candidates) == 0 && len(rest) == 0 && len(request.GetMechanismPreferences()) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "no mechanism supports required features %v", s.requiredFeatures)
+	}
+
+	sortCandidates(candidates, s.preferredFeatures)
+	preferences := make([]*networkservice.Mechanism, 0, len(candidates)+len(rest))
+	for _, c := range candidates {
+		preferences = append(preferences, c.mechanism)
+	}
+	request.MechanismPreferences = append(preferences, rest...)
+
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *capabilitiesServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *capabilitiesServer) candidate(mechanism *networkservice.Mechanism) *candidate {
+	capabilities, ok := s.capabilitiesFor(mechanism.GetType())
+	if !ok {
+		return nil
+	}
+	publish(mechanism, capabilities, DstMaxMTU, DstFeatures)
+	return &candidate{
+		mechanism: mechanism,
+		result:    s.negotiate(mechanism.GetType(), read(mechanism, SrcMaxMTU, SrcFeatures), capabilities),
+	}
+}
+
+// acceptable returns true if the candidate supports the required features, mechanisms without server capabilities
+// are acceptable only if there are no required features
+func (s *capabilitiesServer) acceptable(c *candidate) bool {
+	if c == nil {
+		return len(s.requiredFeatures) == 0
+	}
+	return c.result.supports(s.requiredFeatures)
+}
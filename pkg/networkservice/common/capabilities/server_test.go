@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capabilities_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/capabilities"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/null"
+	"github.com/ljkiraly/sdk/pkg/networkservice/connectioncontext/mtu"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+)
+
+func newRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Cls: cls.REMOTE, Type: vxlan.MECHANISM},
+			{Cls: cls.REMOTE, Type: wireguard.MECHANISM},
+		},
+	}
+}
+
+func newClient(clientOpts, serverOpts []capabilities.Option) networkservice.NetworkServiceClient {
+	return next.NewNetworkServiceClient(
+		capabilities.NewClient(clientOpts...),
+		adapters.NewServerToClient(next.NewNetworkServiceServer(
+			mtu.NewServer(),
+			capabilities.NewServer(serverOpts...),
+			mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
+				vxlan.MECHANISM:     null.NewServer(),
+				wireguard.MECHANISM: null.NewServer(),
+			}),
+		)),
+	)
+}
+
+func TestCapabilities_PreferredFeatures(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	clientOpts := []capabilities.Option{
+		capabilities.WithCapabilities(vxlan.MECHANISM, capabilities.Capabilities{
+			MaxMTU:   1500,
+			Features: []string{capabilities.FeatureChecksumOffload},
+		}),
+		capabilities.WithCapabilities(wireguard.MECHANISM, capabilities.Capabilities{
+			MaxMTU:   9000,
+			Features: []string{capabilities.FeatureEncryption, capabilities.FeatureGSO},
+		}),
+	}
+	serverCapabilities := capabilities.WithDefaultCapabilities(capabilities.Capabilities{
+		MaxMTU:   8000,
+		Features: []string{capabilities.FeatureChecksumOffload, capabilities.FeatureEncryption},
+	})
+
+	// WireGuard has the bigger payload MTU, but the client listed VXLAN first
+	conn, err := newClient(clientOpts, []capabilities.Option{serverCapabilities}).Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	require.Equal(t, vxlan.MECHANISM, conn.GetMechanism().GetType())
+	require.Equal(t, "1430", conn.GetMechanism().GetParameters()[capabilities.PayloadMTU])
+	require.Equal(t, uint32(1430), conn.GetContext().GetMTU())
+
+	// Only WireGuard supports encryption on both sides: min(9000, 8000) - 80
+	conn, err = newClient(clientOpts, []capabilities.Option{
+		serverCapabilities,
+		capabilities.WithPreferredFeatures(capabilities.FeatureEncryption),
+	}).Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	require.Equal(t, wireguard.MECHANISM, conn.GetMechanism().GetType())
+	require.Equal(t, "7920", conn.GetMechanism().GetParameters()[capabilities.PayloadMTU])
+	require.Equal(t, capabilities.FeatureEncryption, conn.GetMechanism().GetParameters()[capabilities.Features])
+	require.Equal(t, uint32(7920), conn.GetContext().GetMTU())
+}
+
+func TestCapabilities_MechanismMTUUntouched(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	client := newClient(
+		[]capabilities.Option{
+			capabilities.WithDefaultCapabilities(capabilities.Capabilities{MaxMTU: 1500}),
+		},
+		[]capabilities.Option{
+			capabilities.WithDefaultCapabilities(capabilities.Capabilities{MaxMTU: 1500}),
+		},
+	)
+
+	// The tunnel interface MTU set by the forwarder is not overwritten by the payload MTU
+	request := newRequest()
+	request.GetMechanismPreferences()[0].Parameters = map[string]string{common.MTU: "1500"}
+
+	conn, err := client.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, "1500", conn.GetMechanism().GetParameters()[common.MTU])
+	require.Equal(t, "1430", conn.GetMechanism().GetParameters()[capabilities.PayloadMTU])
+	require.Equal(t, uint32(1430), conn.GetContext().GetMTU())
+}
+
+func TestCapabilities_MTUOverhead(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	client := newClient(
+		[]capabilities.Option{
+			capabilities.WithDefaultCapabilities(capabilities.Capabilities{MaxMTU: 1500}),
+		},
+		[]capabilities.Option{
+			capabilities.WithCapabilities(vxlan.MECHANISM, capabilities.Capabilities{
+				MaxMTU: 9000,
+				Features: []string{
+					capabilities.FeatureChecksumOffload,
+				},
+			}),
+			capabilities.WithOverhead(vxlan.MECHANISM, 50),
+		},
+	)
+
+	request := newRequest()
+	request.GetConnection().Context = &networkservice.ConnectionContext{MTU: 1500}
+
+	conn, err := client.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, vxlan.MECHANISM, conn.GetMechanism().GetType())
+	require.Equal(t, uint32(1450), conn.GetContext().GetMTU())
+
+	// Refresh renegotiates the selected mechanism
+	refreshRequest := newRequest()
+	refreshRequest.Connection = conn.Clone()
+	conn, err = client.Request(context.Background(), refreshRequest)
+	require.NoError(t, err)
+	require.Equal(t, vxlan.MECHANISM, conn.GetMechanism().GetType())
+	require.Equal(t, uint32(1450), conn.GetContext().GetMTU())
+}
+
+func TestCapabilities_RequiredFeatures(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	clientOpts := []capabilities.Option{
+		capabilities.WithCapabilities(vxlan.MECHANISM, capabilities.Capabilities{MaxMTU: 9000}),
+		capabilities.WithCapabilities(wireguard.MECHANISM, capabilities.Capabilities{
+			MaxMTU:   1500,
+			Features: []string{capabilities.FeatureEncryption},
+		}),
+	}
+	serverCapabilities := capabilities.WithDefaultCapabilities(capabilities.Capabilities{
+		MaxMTU:   9000,
+		Features: []string{capabilities.FeatureEncryption},
+	})
+
+	// VXLAN goes first in the client order, but only WireGuard supports encryption on both sides
+	client := newClient(clientOpts, []capabilities.Option{
+		serverCapabilities,
+		capabilities.WithRequiredFeatures(capabilities.FeatureEncryption),
+	})
+	conn, err := client.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	require.Equal(t, wireguard.MECHANISM, conn.GetMechanism().GetType())
+
+	client = newClient(clientOpts, []capabilities.Option{
+		serverCapabilities,
+		capabilities.WithRequiredFeatures(capabilities.FeatureEncryption, capabilities.FeatureGSO),
+	})
+	_, err = client.Request(context.Background(), newRequest())
+	require.Error(t, err)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capacity
+
+import (
+	"time"
+
+	"github.com/ljkiraly/sdk/pkg/tools/nseregistration"
+)
+
+type options struct {
+	registration *nseregistration.Registration
+	publishDelay time.Duration
+	priorities   map[string]int
+}
+
+// Option is an option pattern for NewServer
+type Option func(*options)
+
+// WithRegistration sets the NSE registration to update with the current load on each change, so that discover
+// prefers the NSEs which are not full. Share the registration with the other elements updating the NSE (e.g. drain),
+// so that they don't overwrite each other's labels.
+func WithRegistration(registration *nseregistration.Registration) Option {
+	return func(o *options) {
+		o.registration = registration
+	}
+}
+
+// WithPublishDelay sets how long the load changes are collected before the NSE is re-registered. Default: 100ms
+func WithPublishDelay(publishDelay time.Duration) Option {
+	return func(o *options) {
+		o.publishDelay = publishDelay
+	}
+}
+
+// WithPriorities sets the connection priorities by the client SPIFFE ID (the subject of the first path segment token).
+// When the NSE is full, a new connection preempts an established connection with a lower priority. Clients missing in
+// priorities have priority 0. Default: all the clients have priority 0, so nothing is preempted.
+func WithPriorities(priorities map[string]int) Option {
+	return func(o *options) {
+		o.priorities = priorities
+	}
+}
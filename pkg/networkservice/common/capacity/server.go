@@ -0,0 +1,236 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package capacity provides a NetworkServiceServer chain element limiting the number of connections on the NSE.
+//
+// When the NSE is full, a new connection with a higher priority (see WithPriorities) preempts the lowest priority
+// connection. The preempted connection is closed via its event factory only after the new connection is established,
+// and gets reselected to another NSE by the client side heal. New connections without a lower priority connection to
+// preempt are rejected with codes.ResourceExhausted.
+package capacity
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/edwarnicke/serialize"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/capacityutils"
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+const defaultPublishDelay = 100 * time.Millisecond
+
+type connectionInfo struct {
+	priority  int
+	factory   begin.EventFactory
+	preempted bool
+}
+
+type capacityServer struct {
+	chainCtx       context.Context
+	maxConnections int
+	options
+
+	mu          sync.Mutex
+	connections map[string]*connectionInfo
+	executor    serialize.Executor
+	pending     atomic.Bool
+}
+
+// NewServer - returns a new capacity chain element accepting up to maxConnections connections. Should be placed
+// after begin and authorize, so the path tokens the priorities are taken from are verified. maxConnections should be
+// positive.
+func NewServer(chainCtx context.Context, maxConnections int, opts ...Option) networkservice.NetworkServiceServer {
+	if maxConnections <= 0 {
+		panic("maxConnections should be positive")
+	}
+	s := &capacityServer{
+		chainCtx:       chainCtx,
+		maxConnections: maxConnections,
+		connections:    make(map[string]*connectionInfo),
+		options: options{
+			publishDelay: defaultPublishDelay,
+		},
+	}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	s.publish()
+	return s
+}
+
+func (s *capacityServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	connID := request.GetConnection().GetId()
+	priority := s.priority(request.GetConnection())
+
+	s.mu.Lock()
+	_, refresh := s.connections[connID]
+	var victimID string
+	var victim *connectionInfo
+	if !refresh {
+		if s.load() >= s.maxConnections {
+			victimID, victim = s.lowestPriority()
+			if victim == nil || victim.priority >= priority {
+				s.mu.Unlock()
+				return nil, status.Errorf(codes.ResourceExhausted, "network service endpoint is full, connection %s with priority %d is rejected", connID, priority)
+			}
+			// The victim is kept until the new connection is established, but it is not counted in the load
+			victim.preempted = true
+		}
+		s.connections[connID] = &connectionInfo{priority: priority}
+	}
+	s.mu.Unlock()
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !refresh {
+			s.mu.Lock()
+			if victim != nil {
+				victim.preempted = false
+			}
+			s.mu.Unlock()
+			s.release(connID)
+		}
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if info, ok := s.connections[connID]; ok {
+		info.factory = begin.FromContext(ctx)
+	}
+	if victim != nil && s.connections[victimID] != victim {
+		// The victim has been closed in the meantime
+		victim = nil
+	}
+	s.mu.Unlock()
+
+	if victim != nil {
+		log.FromContext(ctx).WithField("capacityServer", "Request").
+			Infof("connection %s with priority %d preempts connection %s with priority %d", connID, priority, victimID, victim.priority)
+		s.preempt(ctx, victimID, victim)
+	}
+
+	if !refresh {
+		s.publish()
+	}
+
+	return conn, nil
+}
+
+func (s *capacityServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.release(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// priority returns the priority of the conn client. The client SPIFFE ID is taken from the first path segment token,
+// which is verified by authorize, so the client can't raise its own priority.
+func (s *capacityServer) priority(conn *networkservice.Connection) int {
+	segments := conn.GetPath().GetPathSegments()
+	if len(s.priorities) == 0 || len(segments) == 0 {
+		return 0
+	}
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(segments[0].GetToken(), &claims); err != nil {
+		return 0
+	}
+	return s.priorities[claims.Subject]
+}
+
+// load returns the number of the connections, not counting the preempted ones. Should be called under s.mu.
+func (s *capacityServer) load() (rv int) {
+	for _, info := range s.connections {
+		if !info.preempted {
+			rv++
+		}
+	}
+	return rv
+}
+
+// lowestPriority returns the established connection with the lowest priority, which is not preempted yet. Should be
+// called under s.mu.
+func (s *capacityServer) lowestPriority() (id string, rv *connectionInfo) {
+	for connID, info := range s.connections {
+		if info.factory == nil || info.preempted {
+			continue
+		}
+		if rv == nil || info.priority < rv.priority || info.priority == rv.priority && connID < id {
+			id, rv = connID, info
+		}
+	}
+	return id, rv
+}
+
+func (s *capacityServer) preempt(ctx context.Context, connID string, info *connectionInfo) {
+	logger := log.FromContext(ctx).WithField("capacityServer", "preempt")
+	select {
+	case err := <-info.factory.Close():
+		if err != nil {
+			logger.Warnf("failed to close connection %s: %s", connID, err.Error())
+		}
+	case <-ctx.Done():
+		logger.Warnf("failed to preempt connection %s: %s", connID, ctx.Err().Error())
+	}
+}
+
+func (s *capacityServer) release(connID string) {
+	s.mu.Lock()
+	_, ok := s.connections[connID]
+	delete(s.connections, connID)
+	s.mu.Unlock()
+
+	if ok {
+		s.publish()
+	}
+}
+
+// publish re-registers the NSE with the current load. The changes made during the publish delay are coalesced into
+// a single registration.
+func (s *capacityServer) publish() {
+	if s.registration == nil || !s.pending.CompareAndSwap(false, true) {
+		return
+	}
+	s.executor.AsyncExec(func() {
+		select {
+		case <-s.chainCtx.Done():
+			return
+		case <-clock.FromContext(s.chainCtx).After(s.publishDelay):
+		}
+		s.pending.Store(false)
+
+		s.mu.Lock()
+		load := s.load()
+		s.mu.Unlock()
+
+		if err := s.registration.Update(s.chainCtx, func(nse *registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+			return capacityutils.WithLoad(nse, s.maxConnections, load)
+		}); err != nil {
+			log.FromContext(s.chainCtx).WithField("capacityServer", "publish").Warnf("failed to publish load: %s", err.Error())
+		}
+	})
+}
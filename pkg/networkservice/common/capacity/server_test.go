@@ -0,0 +1,249 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capacity_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/capacity"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/ljkiraly/sdk/pkg/registry/common/memory"
+	registryadapters "github.com/ljkiraly/sdk/pkg/registry/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/tools/capacityutils"
+	"github.com/ljkiraly/sdk/pkg/tools/drainutils"
+	"github.com/ljkiraly/sdk/pkg/tools/nseregistration"
+)
+
+var priorities = map[string]int{
+	"spiffe://test.com/medium": 5,
+	"spiffe://test.com/high":   10,
+}
+
+func newRequest(t *testing.T, connID, client string) *networkservice.NetworkServiceRequest {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: client}).SignedString([]byte("key"))
+	require.NoError(t, err)
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: connID,
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Token: token}},
+			},
+		},
+	}
+}
+
+func TestCapacityServer_Preempt(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	counter := new(count.Server)
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		capacity.NewServer(ctx, 2, capacity.WithPriorities(priorities)),
+		counter,
+	)
+
+	_, err := server.Request(ctx, newRequest(t, "low", "spiffe://test.com/low"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, newRequest(t, "medium", "spiffe://test.com/medium"))
+	require.NoError(t, err)
+
+	// Refresh of the established connection is not limited
+	_, err = server.Request(ctx, newRequest(t, "low", "spiffe://test.com/low"))
+	require.NoError(t, err)
+
+	// Nothing to preempt for the same priority
+	_, err = server.Request(ctx, newRequest(t, "other-low", "spiffe://test.com/low"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = server.Request(ctx, newRequest(t, "high", "spiffe://test.com/high"))
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.Closes())
+
+	_, err = server.Request(ctx, newRequest(t, "other-high", "spiffe://test.com/high"))
+	require.NoError(t, err)
+	require.Equal(t, 2, counter.Closes())
+
+	_, err = server.Request(ctx, newRequest(t, "another-high", "spiffe://test.com/high"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Closed connection frees the capacity
+	_, err = server.Close(ctx, &networkservice.Connection{Id: "high"})
+	require.NoError(t, err)
+	_, err = server.Request(ctx, newRequest(t, "low", "spiffe://test.com/low"))
+	require.NoError(t, err)
+}
+
+func TestCapacityServer_IgnoresClientPriorityLabel(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	counter := new(count.Server)
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		capacity.NewServer(ctx, 1, capacity.WithPriorities(priorities)),
+		counter,
+	)
+
+	_, err := server.Request(ctx, newRequest(t, "low", "spiffe://test.com/low"))
+	require.NoError(t, err)
+
+	request := newRequest(t, "other-low", "spiffe://test.com/low")
+	request.GetConnection().Labels = map[string]string{"nsm.priority": "100"}
+	_, err = server.Request(ctx, request)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, 0, counter.Closes())
+}
+
+func TestCapacityServer_PreemptAfterRequest(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	counter := new(count.Server)
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		capacity.NewServer(ctx, 1, capacity.WithPriorities(priorities)),
+		counter,
+		injecterror.NewServer(injecterror.WithRequestErrorTimes(1), injecterror.WithCloseErrorTimes()),
+	)
+
+	_, err := server.Request(ctx, newRequest(t, "low", "spiffe://test.com/low"))
+	require.NoError(t, err)
+
+	// Failed request doesn't preempt the established connection
+	_, err = server.Request(ctx, newRequest(t, "high", "spiffe://test.com/high"))
+	require.Error(t, err)
+	require.Equal(t, 0, counter.Closes())
+
+	_, err = server.Request(ctx, newRequest(t, "other-low", "spiffe://test.com/low"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = server.Request(ctx, newRequest(t, "high", "spiffe://test.com/high"))
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.Closes())
+
+	_, err = server.Request(ctx, newRequest(t, "other-low", "spiffe://test.com/low"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestCapacityServer_Registration(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	nseClient := registryadapters.NetworkServiceEndpointServerToClient(memory.NewNetworkServiceEndpointRegistryServer())
+	nse, err := nseClient.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{"ns"},
+	})
+	require.NoError(t, err)
+
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		capacity.NewServer(ctx, 1, capacity.WithRegistration(nseregistration.New(nseClient, nse))),
+	)
+
+	isFull := func() bool {
+		stream, err := nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse"},
+		})
+		require.NoError(t, err)
+		nses := registry.ReadNetworkServiceEndpointList(stream)
+		require.Len(t, nses, 1)
+		return capacityutils.IsFull(nses[0])
+	}
+
+	require.Eventually(t, func() bool { return !isFull() }, time.Second/2, time.Millisecond*10)
+
+	_, err = server.Request(ctx, newRequest(t, "conn", "spiffe://test.com/nsc"))
+	require.NoError(t, err)
+	require.Eventually(t, isFull, time.Second/2, time.Millisecond*10)
+
+	_, err = server.Close(ctx, &networkservice.Connection{Id: "conn"})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return !isFull() }, time.Second/2, time.Millisecond*10)
+}
+
+func TestCapacityServer_KeepsDrainingMark(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	nseClient := registryadapters.NetworkServiceEndpointServerToClient(memory.NewNetworkServiceEndpointRegistryServer())
+	nse, err := nseClient.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{"ns"},
+	})
+	require.NoError(t, err)
+
+	registration := nseregistration.New(nseClient, nse)
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		capacity.NewServer(ctx, 2, capacity.WithRegistration(registration), capacity.WithPublishDelay(0)),
+	)
+
+	find := func() *registry.NetworkServiceEndpoint {
+		stream, err := nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse"},
+		})
+		require.NoError(t, err)
+		nses := registry.ReadNetworkServiceEndpointList(stream)
+		require.Len(t, nses, 1)
+		return nses[0]
+	}
+
+	_, err = server.Request(ctx, newRequest(t, "conn-1", "spiffe://test.com/nsc"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, newRequest(t, "conn-2", "spiffe://test.com/nsc"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return capacityutils.IsFull(find()) }, time.Second/2, time.Millisecond*10)
+
+	// Drain marks the NSE, the following load updates should keep the mark
+	require.NoError(t, registration.Update(ctx, drainutils.Mark))
+
+	_, err = server.Close(ctx, &networkservice.Connection{Id: "conn-1"})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return !capacityutils.IsFull(find()) }, time.Second/2, time.Millisecond*10)
+	require.True(t, drainutils.IsDraining(find()))
+}
+
+func TestCapacityServer_InvalidMaxConnections(t *testing.T) {
+	require.Panics(t, func() {
+		capacity.NewServer(context.Background(), 0)
+	})
+}
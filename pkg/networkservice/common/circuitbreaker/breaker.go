@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"sync"
+	"time"
+)
+
+// State is a circuit state
+type State int
+
+const (
+	// Closed - the NSE is selected as usual
+	Closed State = iota
+	// Open - the NSE is excluded from the candidates
+	Open
+	// HalfOpen - a single probe request to the NSE is in progress
+	HalfOpen
+)
+
+type circuit struct {
+	state     State
+	results   []bool
+	openUntil time.Time
+}
+
+// Breaker keeps per-NSE circuits based on the failure rate of the last Request results
+type Breaker struct {
+	options
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// NewBreaker creates a new Breaker
+func NewBreaker(opts ...Option) *Breaker {
+	b := &Breaker{
+		options: options{
+			windowSize:           defaultWindowSize,
+			minRequests:          defaultMinRequests,
+			failureRateThreshold: defaultFailureRateThreshold,
+			cooldown:             defaultCooldown,
+		},
+		circuits: make(map[string]*circuit),
+	}
+	for _, opt := range opts {
+		opt(&b.options)
+	}
+	return b
+}
+
+// Allow returns true if a Request to the NSE is allowed at the moment: the circuit is closed, or the cooldown has
+// passed and there is no probe in progress. It has no side effects, so it can be used to filter the candidates.
+func (b *Breaker) Allow(name string, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[name]
+	return !ok || c.state == Closed || !now.Before(c.openUntil)
+}
+
+// Probe should be called for the NSE actually selected for the Request. Once the cooldown passes, the circuit becomes
+// half-open and the Request takes the single probe slot until its result is recorded or the cooldown passes again.
+// Returns false if the circuit doesn't allow the Request.
+func (b *Breaker) Probe(name string, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[name]
+	if !ok || c.state == Closed {
+		return true
+	}
+	if now.Before(c.openUntil) {
+		return false
+	}
+	c.state = HalfOpen
+	c.openUntil = now.Add(b.cooldown)
+	return true
+}
+
+// Record records the Request result for the NSE
+func (b *Breaker) Record(name string, now time.Time, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[name]
+	if !ok {
+		c = new(circuit)
+		b.circuits[name] = c
+	}
+
+	if c.state != Closed {
+		if err == nil {
+			*c = circuit{}
+			return
+		}
+		c.state = Open
+		c.openUntil = now.Add(b.cooldown)
+		return
+	}
+
+	c.results = append(c.results, err != nil)
+	if len(c.results) > b.windowSize {
+		c.results = c.results[len(c.results)-b.windowSize:]
+	}
+	if len(c.results) < b.minRequests {
+		return
+	}
+	failures := 0
+	for _, failed := range c.results {
+		if failed {
+			failures++
+		}
+	}
+	if float64(failures)/float64(len(c.results)) >= b.failureRateThreshold {
+		c.state = Open
+		c.results = nil
+		c.openUntil = now.Add(b.cooldown)
+	}
+}
+
+// State returns the current circuit state for the NSE
+func (b *Breaker) State(name string) State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c, ok := b.circuits[name]; ok {
+		return c.state
+	}
+	return Closed
+}
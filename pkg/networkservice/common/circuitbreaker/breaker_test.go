@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/circuitbreaker"
+)
+
+func TestBreaker_FailureRate(t *testing.T) {
+	breaker := circuitbreaker.NewBreaker(
+		circuitbreaker.WithWindowSize(4),
+		circuitbreaker.WithMinRequests(4),
+		circuitbreaker.WithFailureRateThreshold(0.5),
+	)
+	now := time.Now()
+
+	breaker.Record(badNSE, now, errors.New("error"))
+	breaker.Record(badNSE, now, nil)
+	breaker.Record(badNSE, now, nil)
+	require.Equal(t, circuitbreaker.Closed, breaker.State(badNSE))
+
+	breaker.Record(badNSE, now, nil)
+	breaker.Record(badNSE, now, errors.New("error"))
+	require.Equal(t, circuitbreaker.Closed, breaker.State(badNSE))
+
+	breaker.Record(badNSE, now, errors.New("error"))
+	require.Equal(t, circuitbreaker.Open, breaker.State(badNSE))
+	require.False(t, breaker.Allow(badNSE, now))
+}
+
+func TestBreaker_InvalidOptions(t *testing.T) {
+	require.Panics(t, func() { circuitbreaker.WithMinRequests(0) })
+	require.Panics(t, func() { circuitbreaker.WithFailureRateThreshold(0) })
+	require.Panics(t, func() { circuitbreaker.WithFailureRateThreshold(1.5) })
+	require.NotPanics(t, func() { circuitbreaker.WithFailureRateThreshold(1) })
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	breaker := circuitbreaker.NewBreaker(
+		circuitbreaker.WithMinRequests(1),
+		circuitbreaker.WithFailureRateThreshold(1),
+		circuitbreaker.WithCooldown(time.Second),
+	)
+	now := time.Now()
+
+	breaker.Record(badNSE, now, errors.New("error"))
+	require.False(t, breaker.Allow(badNSE, now.Add(time.Second/2)))
+
+	// Allow doesn't take the probe, so the NSE filtered in but not selected keeps it
+	now = now.Add(time.Second)
+	require.True(t, breaker.Allow(badNSE, now))
+	require.True(t, breaker.Allow(badNSE, now))
+	require.Equal(t, circuitbreaker.Open, breaker.State(badNSE))
+
+	// Single probe after the cooldown
+	require.True(t, breaker.Probe(badNSE, now))
+	require.Equal(t, circuitbreaker.HalfOpen, breaker.State(badNSE))
+	require.False(t, breaker.Allow(badNSE, now))
+	require.False(t, breaker.Probe(badNSE, now))
+
+	// Failed probe opens the circuit again
+	breaker.Record(badNSE, now, errors.New("error"))
+	require.Equal(t, circuitbreaker.Open, breaker.State(badNSE))
+	require.False(t, breaker.Allow(badNSE, now.Add(time.Second/2)))
+
+	// Unused probe expires after the cooldown
+	now = now.Add(time.Second)
+	require.True(t, breaker.Probe(badNSE, now))
+	now = now.Add(time.Second)
+	require.True(t, breaker.Allow(badNSE, now))
+	require.True(t, breaker.Probe(badNSE, now))
+
+	// Successful probe closes the circuit
+	breaker.Record(badNSE, now, nil)
+	require.Equal(t, circuitbreaker.Closed, breaker.State(badNSE))
+	require.True(t, breaker.Allow(badNSE, now))
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import "time"
+
+const (
+	defaultWindowSize           = 10
+	defaultMinRequests          = 3
+	defaultFailureRateThreshold = 0.5
+	defaultCooldown             = time.Second * 30
+)
+
+type options struct {
+	windowSize           int
+	minRequests          int
+	failureRateThreshold float64
+	cooldown             time.Duration
+}
+
+// Option is an option pattern for NewBreaker
+type Option func(*options)
+
+// WithWindowSize sets the number of the last results the failure rate is computed from. Default: 10
+func WithWindowSize(windowSize int) Option {
+	return func(o *options) {
+		if windowSize > 0 {
+			o.windowSize = windowSize
+		}
+	}
+}
+
+// WithMinRequests sets the minimal number of results required to open the circuit, should be positive. Default: 3
+func WithMinRequests(minRequests int) Option {
+	if minRequests <= 0 {
+		panic("minRequests should be positive")
+	}
+	return func(o *options) {
+		o.minRequests = minRequests
+	}
+}
+
+// WithFailureRateThreshold sets the failure rate (0..1] opening the circuit. Default: 0.5
+func WithFailureRateThreshold(threshold float64) Option {
+	if threshold <= 0 || threshold > 1 {
+		panic("failureRateThreshold should be in (0, 1]")
+	}
+	return func(o *options) {
+		o.failureRateThreshold = threshold
+	}
+}
+
+// WithCooldown sets the time the circuit stays open before the half-open probe. Default: 30s
+func WithCooldown(cooldown time.Duration) Option {
+	return func(o *options) {
+		o.cooldown = cooldown
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package circuitbreaker provides NetworkServiceServer chain elements excluding the NSEs which keep failing Requests
+// from the candidates provided by discover.
+//
+// NewServer filters discover.Candidates(ctx) and should be placed right after discover. NewRecorderServer takes the
+// half-open probe and records the result of each Request to the selected NSE and should be placed after the NSE
+// selection (e.g. roundrobin), so that the NSEs filtered in but not selected keep their probe.
+// Both share the same Breaker.
+package circuitbreaker
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/discover"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+type circuitBreakerServer struct {
+	breaker *Breaker
+}
+
+// NewServer - returns a new chain element excluding the NSEs with open circuit from discover.Candidates(ctx).
+// If all the candidates are excluded, the original list is kept.
+func NewServer(breaker *Breaker) networkservice.NetworkServiceServer {
+	return &circuitBreakerServer{
+		breaker: breaker,
+	}
+}
+
+func (s *circuitBreakerServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	candidates := discover.Candidates(ctx)
+	if candidates == nil || len(candidates.Endpoints) == 0 {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	now := clock.FromContext(ctx).Now()
+	var endpoints []*registry.NetworkServiceEndpoint
+	for _, nse := range candidates.Endpoints {
+		if s.breaker.Allow(nse.GetName(), now) {
+			endpoints = append(endpoints, nse)
+		}
+	}
+
+	switch len(endpoints) {
+	case len(candidates.Endpoints):
+	case 0:
+		log.FromContext(ctx).WithField("circuitBreakerServer", "Request").Warn("all the candidates have open circuit")
+	default:
+		ctx = discover.WithCandidates(ctx, endpoints, candidates.NetworkService)
+	}
+
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *circuitBreakerServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+type recorderServer struct {
+	breaker *Breaker
+}
+
+// NewRecorderServer - returns a new chain element taking the half-open probe for the selected NSE and recording the
+// Request results for it
+func NewRecorderServer(breaker *Breaker) networkservice.NetworkServiceServer {
+	return &recorderServer{
+		breaker: breaker,
+	}
+}
+
+func (s *recorderServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	name := request.GetConnection().GetNetworkServiceEndpointName()
+	if name != "" {
+		_ = s.breaker.Probe(name, clock.FromContext(ctx).Now())
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+
+	// Canceled requests say nothing about the NSE
+	if name != "" && ctx.Err() == nil {
+		s.breaker.Record(name, clock.FromContext(ctx).Now(), err)
+	}
+
+	return conn, err
+}
+
+func (s *recorderServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
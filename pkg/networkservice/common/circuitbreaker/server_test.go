@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/circuitbreaker"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/discover"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/roundrobin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/switchcase"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/clockmock"
+)
+
+const (
+	badNSE  = "nse-bad"
+	goodNSE = "nse-good"
+	ns      = "ns"
+)
+
+func testCandidates(ctx context.Context) context.Context {
+	return discover.WithCandidates(ctx, []*registry.NetworkServiceEndpoint{
+		{
+			Name:                badNSE,
+			Url:                 "unix://" + badNSE,
+			NetworkServiceNames: []string{ns},
+		},
+		{
+			Name:                goodNSE,
+			Url:                 "unix://" + goodNSE,
+			NetworkServiceNames: []string{ns},
+		},
+	}, &registry.NetworkService{Name: ns})
+}
+
+func testServer(breaker *circuitbreaker.Breaker, badCounter *count.Server, badErr bool) networkservice.NetworkServiceServer {
+	badServer := next.NewNetworkServiceServer(badCounter)
+	if badErr {
+		badServer = next.NewNetworkServiceServer(badCounter, injecterror.NewServer())
+	}
+	return next.NewNetworkServiceServer(
+		circuitbreaker.NewServer(breaker),
+		roundrobin.NewServer(),
+		circuitbreaker.NewRecorderServer(breaker),
+		switchcase.NewServer(&switchcase.ServerCase{
+			Condition: func(_ context.Context, conn *networkservice.Connection) bool {
+				return conn.GetNetworkServiceEndpointName() == badNSE
+			},
+			Server: badServer,
+		}),
+	)
+}
+
+func TestCircuitBreakerServer_ExcludesFailingNSE(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	breaker := circuitbreaker.NewBreaker(
+		circuitbreaker.WithMinRequests(1),
+		circuitbreaker.WithFailureRateThreshold(1),
+		circuitbreaker.WithCooldown(time.Minute),
+	)
+	badCounter := new(count.Server)
+	server := testServer(breaker, badCounter, true)
+
+	for i := 0; i < 10; i++ {
+		conn, err := server.Request(testCandidates(ctx), &networkservice.NetworkServiceRequest{
+			Connection: new(networkservice.Connection),
+		})
+		require.NoError(t, err)
+		require.Equal(t, goodNSE, conn.GetNetworkServiceEndpointName())
+	}
+
+	require.Equal(t, 1, badCounter.Requests())
+	require.Equal(t, circuitbreaker.Open, breaker.State(badNSE))
+	require.Equal(t, circuitbreaker.Closed, breaker.State(goodNSE))
+}
+
+func TestCircuitBreakerServer_AllOpen(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	breaker := circuitbreaker.NewBreaker(
+		circuitbreaker.WithMinRequests(1),
+		circuitbreaker.WithFailureRateThreshold(1),
+	)
+	breaker.Record(badNSE, clockMock.Now(), errors.New("error"))
+	breaker.Record(goodNSE, clockMock.Now(), errors.New("error"))
+
+	badCounter := new(count.Server)
+	server := testServer(breaker, badCounter, false)
+
+	conn, err := server.Request(testCandidates(ctx), &networkservice.NetworkServiceRequest{
+		Connection: new(networkservice.Connection),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, conn.GetNetworkServiceEndpointName())
+}
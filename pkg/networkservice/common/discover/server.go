@@ -29,8 +29,11 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/capacityutils"
 	"github.com/ljkiraly/sdk/pkg/tools/clienturlctx"
 	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/drainutils"
+	"github.com/ljkiraly/sdk/pkg/tools/exclusionutils"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
 	"github.com/ljkiraly/sdk/pkg/tools/matchutils"
 )
@@ -71,7 +74,7 @@ func (d *discoverCandidatesServer) Request(ctx context.Context, request *network
 	if err != nil {
 		return nil, err
 	}
-	nses, err := d.discoverNetworkServiceEndpoints(ctx, ns, request.GetConnection().GetLabels())
+	nses, err := d.discoverNetworkServiceEndpoints(ctx, ns, request.GetConnection())
 	if err != nil {
 		return nil, err
 	}
@@ -134,7 +137,7 @@ func (d *discoverCandidatesServer) discoverNetworkServiceEndpoint(ctx context.Co
 	return nil, errors.Errorf("network service endpoint %v not found", nseName)
 }
 
-func (d *discoverCandidatesServer) discoverNetworkServiceEndpoints(ctx context.Context, ns *registry.NetworkService, nsLabels map[string]string) ([]*registry.NetworkServiceEndpoint, error) {
+func (d *discoverCandidatesServer) discoverNetworkServiceEndpoints(ctx context.Context, ns *registry.NetworkService, conn *networkservice.Connection) ([]*registry.NetworkServiceEndpoint, error) {
 	clockTime := clock.FromContext(ctx)
 
 	query := &registry.NetworkServiceEndpointQuery{
@@ -149,9 +152,11 @@ func (d *discoverCandidatesServer) discoverNetworkServiceEndpoints(ctx context.C
 	}
 	nseList := registry.ReadNetworkServiceEndpointList(nseRespStream)
 
-	result := matchutils.MatchEndpoint(nsLabels, ns, validateExpirationTime(clockTime, nseList)...)
+	nseList = exclusionutils.Filter(conn, drainutils.Filter(validateExpirationTime(clockTime, nseList)))
+
+	result := matchutils.MatchEndpoint(conn.GetLabels(), ns, nseList...)
 	if len(result) != 0 {
-		return result, nil
+		return capacityutils.Prefer(result), nil
 	}
 
 	return nil, errors.New("network service endpoint candidates not found")
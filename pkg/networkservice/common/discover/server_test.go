@@ -37,7 +37,10 @@ import (
 	"github.com/ljkiraly/sdk/pkg/registry/common/memory"
 	registryadapters "github.com/ljkiraly/sdk/pkg/registry/core/adapters"
 	registrynext "github.com/ljkiraly/sdk/pkg/registry/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/capacityutils"
 	"github.com/ljkiraly/sdk/pkg/tools/clienturlctx"
+	"github.com/ljkiraly/sdk/pkg/tools/drainutils"
+	"github.com/ljkiraly/sdk/pkg/tools/exclusionutils"
 	"github.com/ljkiraly/sdk/pkg/tools/matchutils"
 )
 
@@ -409,6 +412,107 @@ func TestDiscoverCandidatesServer_NoMatchServiceEndpointFound(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestDiscoverCandidatesServer_SkipDrainingEndpoints(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), testWait)
+	defer cancel()
+
+	nsName := networkServiceName()
+
+	nses := endpoints()
+	nses[1] = drainutils.Mark(nses[1])
+	nsServer, nseServer := testServers(t, nsName, nses)
+
+	server := next.NewNetworkServiceServer(
+		discover.NewServer(
+			registryadapters.NetworkServiceServerToClient(nsServer),
+			registryadapters.NetworkServiceEndpointServerToClient(nseServer)),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			nses := discover.Candidates(ctx).Endpoints
+			require.Len(t, nses, 2)
+			for _, nse := range nses {
+				require.NotEqual(t, "nse-2", nse.Name)
+			}
+		}),
+	)
+
+	_, err := server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			NetworkService: nsName,
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestDiscoverCandidatesServer_SkipExcludedEndpoint(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), testWait)
+	defer cancel()
+
+	nsName := networkServiceName()
+
+	nsServer, nseServer := testServers(t, nsName, endpoints())
+
+	server := next.NewNetworkServiceServer(
+		discover.NewServer(
+			registryadapters.NetworkServiceServerToClient(nsServer),
+			registryadapters.NetworkServiceEndpointServerToClient(nseServer)),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			nses := discover.Candidates(ctx).Endpoints
+			require.Len(t, nses, 2)
+			for _, nse := range nses {
+				require.NotEqual(t, "nse-1", nse.Name)
+			}
+		}),
+	)
+
+	conn := &networkservice.Connection{
+		NetworkService: nsName,
+	}
+	exclusionutils.Exclude(conn, "nse-1")
+
+	_, err := server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: conn,
+	})
+	require.NoError(t, err)
+}
+
+func TestDiscoverCandidatesServer_PreferNotFullEndpoints(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), testWait)
+	defer cancel()
+
+	nsName := networkServiceName()
+
+	nses := endpoints()
+	nses[0] = capacityutils.WithLoad(nses[0], 2, 1)
+	nses[1] = capacityutils.WithLoad(nses[1], 2, 2)
+	nsServer, nseServer := testServers(t, nsName, nses)
+
+	server := next.NewNetworkServiceServer(
+		discover.NewServer(
+			registryadapters.NetworkServiceServerToClient(nsServer),
+			registryadapters.NetworkServiceEndpointServerToClient(nseServer)),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			nses := discover.Candidates(ctx).Endpoints
+			require.Len(t, nses, 2)
+			for _, nse := range nses {
+				require.NotEqual(t, "nse-2", nse.Name)
+			}
+		}),
+	)
+
+	_, err := server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			NetworkService: nsName,
+		},
+	})
+	require.NoError(t, err)
+}
+
 func TestDiscoverCandidatesServer_MatchExactService(t *testing.T) {
 	t.Cleanup(func() { goleak.VerifyNone(t) })
 
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drain
+
+import (
+	"time"
+
+	"github.com/ljkiraly/sdk/pkg/tools/nseregistration"
+)
+
+type options struct {
+	batchSize       int
+	batchInterval   time.Duration
+	reselectTimeout time.Duration
+	registration    *nseregistration.Registration
+}
+
+// Option is an option pattern for NewServer
+type Option func(*options)
+
+// WithBatchSize sets how many connections are reselected at once while draining. Default: 10
+func WithBatchSize(batchSize int) Option {
+	return func(o *options) {
+		if batchSize > 0 {
+			o.batchSize = batchSize
+		}
+	}
+}
+
+// WithBatchInterval sets the delay between two consecutive batches. Default: 1s
+func WithBatchInterval(batchInterval time.Duration) Option {
+	return func(o *options) {
+		o.batchInterval = batchInterval
+	}
+}
+
+// WithReselectTimeout sets how long the clients have to move the reselected connections away, the connections left
+// after it are closed. Default: 1m
+func WithReselectTimeout(reselectTimeout time.Duration) Option {
+	return func(o *options) {
+		if reselectTimeout > 0 {
+			o.reselectTimeout = reselectTimeout
+		}
+	}
+}
+
+// WithRegistration sets the NSE registration to update marked as draining before reselecting the connections. Share the
+// registration with the other elements updating the NSE (e.g. capacity), so that they don't drop the draining mark.
+func WithRegistration(registration *nseregistration.Registration) Option {
+	return func(o *options) {
+		o.registration = registration
+	}
+}
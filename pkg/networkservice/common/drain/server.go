@@ -0,0 +1,245 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drain provides a NetworkServiceServer chain element taking the NSE out of service without connection drops.
+//
+// Drain marks the NSE as draining in the registry, so discover stops selecting it for the new connections, and then
+// requests the clients to reselect the established connections in batches: it sends a monitor UPDATE event with
+// RESELECT_REQUESTED state for each connection, so the client makes the new connection to another NSE before closing
+// this one. The next batch starts once the clients have moved the previous one away. Connections that are not
+// monitored or not moved away in time are closed. Use it together with onidle to stop the NSE once it becomes empty.
+package drain
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/monitor"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/drainutils"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+const (
+	defaultBatchSize       = 10
+	defaultBatchInterval   = time.Second
+	defaultReselectTimeout = time.Minute
+)
+
+type entry struct {
+	conn          *networkservice.Connection
+	factory       begin.EventFactory
+	eventConsumer monitor.EventConsumer
+	closed        chan struct{}
+}
+
+// Server is a drain chain element. Should be placed after begin, metadata and monitor.
+type Server struct {
+	options
+	draining    atomic.Bool
+	connections genericsync.Map[string, *entry]
+}
+
+// NewServer - returns a new drain chain element
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		options: options{
+			batchSize:       defaultBatchSize,
+			batchInterval:   defaultBatchInterval,
+			reselectTimeout: defaultReselectTimeout,
+		},
+	}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	return s
+}
+
+// Request rejects the new connections once the NSE is draining. Refreshes of the established connections are passed
+// through.
+func (s *Server) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	connID := request.GetConnection().GetId()
+	prev, loaded := s.connections.Load(connID)
+	if !loaded && s.draining.Load() {
+		return nil, status.Errorf(codes.Unavailable, "network service endpoint is draining, connection %s is rejected", connID)
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &entry{
+		conn:    conn.Clone(),
+		factory: begin.FromContext(ctx),
+		closed:  make(chan struct{}),
+	}
+	if loaded {
+		e.closed = prev.closed
+	}
+	e.eventConsumer, _ = monitor.LoadEventConsumer(ctx, false)
+	s.connections.Store(conn.GetId(), e)
+
+	return conn, nil
+}
+
+// Close closes the connection
+func (s *Server) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.forget(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// IsDraining returns true if Drain has been called
+func (s *Server) IsDraining() bool {
+	return s.draining.Load()
+}
+
+// Drain marks the NSE as draining and moves all its connections away in batches. It returns once there are no more
+// connections left or ctx is done.
+func (s *Server) Drain(ctx context.Context) error {
+	s.draining.Store(true)
+
+	logger := log.FromContext(ctx).WithField("drainServer", "Drain")
+
+	if s.registration != nil {
+		if err := s.registration.Update(ctx, drainutils.Mark); err != nil {
+			return errors.Wrap(err, "failed to mark NSE as draining")
+		}
+	}
+
+	clockTime := clock.FromContext(ctx)
+	for {
+		batch := make(map[string]*entry, s.batchSize)
+		s.connections.Range(func(id string, e *entry) bool {
+			batch[id] = e
+			return len(batch) < s.batchSize
+		})
+		if len(batch) == 0 {
+			return nil
+		}
+
+		var reselected []*entry
+		var results []<-chan error
+		for id, e := range batch {
+			if err := reselect(e); err != nil {
+				logger.Warnf("failed to request reselect, closing connection %s: %s", id, err.Error())
+				results = append(results, s.close(id, e))
+				continue
+			}
+			logger.Debugf("reselect requested: %s", id)
+			reselected = append(reselected, e)
+		}
+
+		if err := waitClosed(ctx, results); err != nil {
+			return err
+		}
+		if err := s.waitMoved(ctx, reselected); err != nil {
+			return err
+		}
+
+		if s.batchInterval == 0 || s.isEmpty() {
+			continue
+		}
+		select {
+		case <-clockTime.After(s.batchInterval):
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "drain is canceled")
+		}
+	}
+}
+
+// reselect requests the client to reselect the connection with the monitor event
+func reselect(e *entry) error {
+	if e.eventConsumer == nil {
+		return errors.New("connection is not monitored")
+	}
+	conn := e.conn.Clone()
+	conn.State = networkservice.State_RESELECT_REQUESTED
+	return e.eventConsumer.Send(&networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_UPDATE,
+		Connections: map[string]*networkservice.Connection{conn.GetId(): conn},
+	})
+}
+
+// waitMoved waits for the clients to close the reselected connections and closes the ones left after the timeout
+func (s *Server) waitMoved(ctx context.Context, reselected []*entry) error {
+	timeoutCh := clock.FromContext(ctx).After(s.reselectTimeout)
+	for _, e := range reselected {
+		select {
+		case <-e.closed:
+		case <-timeoutCh:
+			var results []<-chan error
+			for _, e := range reselected {
+				select {
+				case <-e.closed:
+				default:
+					log.FromContext(ctx).WithField("drainServer", "Drain").Warnf("connection has not been moved away in time, closing it: %s", e.conn.GetId())
+					results = append(results, s.close(e.conn.GetId(), e))
+				}
+			}
+			return waitClosed(ctx, results)
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "drain is canceled")
+		}
+	}
+	return nil
+}
+
+// close closes the connection with its event factory
+func (s *Server) close(id string, e *entry) <-chan error {
+	s.forget(id)
+	return e.factory.Close()
+}
+
+func waitClosed(ctx context.Context, results []<-chan error) error {
+	for _, ch := range results {
+		select {
+		case err := <-ch:
+			if err != nil {
+				log.FromContext(ctx).WithField("drainServer", "Drain").Warnf("failed to close connection: %s", err.Error())
+			}
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "drain is canceled")
+		}
+	}
+	return nil
+}
+
+func (s *Server) forget(id string) {
+	if e, ok := s.connections.LoadAndDelete(id); ok {
+		close(e.closed)
+	}
+}
+
+func (s *Server) isEmpty() bool {
+	rv := true
+	s.connections.Range(func(string, *entry) bool {
+		rv = false
+		return false
+	})
+	return rv
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drain_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/drain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/monitor"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/onidle"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/registry/common/memory"
+	registryadapters "github.com/ljkiraly/sdk/pkg/registry/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/clockmock"
+	"github.com/ljkiraly/sdk/pkg/tools/drainutils"
+	"github.com/ljkiraly/sdk/pkg/tools/nseregistration"
+)
+
+func newRequest(connID string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: connID,
+		},
+	}
+}
+
+func TestDrainServer_Drain(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	nseClient := registryadapters.NetworkServiceEndpointServerToClient(memory.NewNetworkServiceEndpointRegistryServer())
+	nse, err := nseClient.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{"ns"},
+	})
+	require.NoError(t, err)
+
+	idleCh := make(chan struct{})
+	counter := new(count.Server)
+	drainServer := drain.NewServer(
+		drain.WithBatchSize(2),
+		drain.WithBatchInterval(0),
+		drain.WithRegistration(nseregistration.New(nseClient, nse)),
+	)
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		onidle.NewServer(ctx, func() { close(idleCh) }, time.Millisecond*100),
+		drainServer,
+		counter,
+	)
+
+	for i := 0; i < 5; i++ {
+		_, err = server.Request(ctx, newRequest(strconv.Itoa(i)))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, drainServer.Drain(ctx))
+	require.True(t, drainServer.IsDraining())
+	require.Equal(t, 5, counter.Closes())
+
+	stream, err := nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse"},
+	})
+	require.NoError(t, err)
+	nses := registry.ReadNetworkServiceEndpointList(stream)
+	require.Len(t, nses, 1)
+	require.True(t, drainutils.IsDraining(nses[0]))
+
+	_, err = server.Request(ctx, newRequest("new"))
+	require.Error(t, err)
+
+	select {
+	case <-idleCh:
+	case <-ctx.Done():
+		require.FailNow(t, "onidle notify has not been called")
+	}
+}
+
+// reselectOnEvent closes the connections on RESELECT_REQUESTED events the way the client moves them away
+func reselectOnEvent(ctx context.Context, t *testing.T, monitorServer networkservice.MonitorConnectionServer, server networkservice.NetworkServiceServer) {
+	monitorClient, err := adapters.NewMonitorServerToClient(monitorServer).MonitorConnections(ctx, &networkservice.MonitorScopeSelector{
+		PathSegments: []*networkservice.PathSegment{{Name: "nsc"}},
+	})
+	require.NoError(t, err)
+	go func() {
+		for {
+			event, err := monitorClient.Recv()
+			if err != nil {
+				return
+			}
+			for _, conn := range event.GetConnections() {
+				if conn.GetState() == networkservice.State_RESELECT_REQUESTED {
+					_, _ = server.Close(ctx, conn)
+				}
+			}
+		}
+	}()
+}
+
+func newMonitoredRequest(connID string) *networkservice.NetworkServiceRequest {
+	request := newRequest(connID)
+	request.Connection.Path = &networkservice.Path{
+		PathSegments: []*networkservice.PathSegment{{Name: "nsc", Id: connID}},
+	}
+	return request
+}
+
+func TestDrainServer_Batches(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	counter := new(count.Server)
+	drainServer := drain.NewServer(
+		drain.WithBatchSize(2),
+		drain.WithBatchInterval(time.Minute),
+		drain.WithReselectTimeout(time.Hour),
+	)
+	var monitorServer networkservice.MonitorConnectionServer
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		monitor.NewServer(ctx, &monitorServer),
+		drainServer,
+		counter,
+	)
+	reselectOnEvent(ctx, t, monitorServer, server)
+
+	for i := 0; i < 5; i++ {
+		_, err := server.Request(ctx, newMonitoredRequest(strconv.Itoa(i)))
+		require.NoError(t, err)
+	}
+
+	// Refreshes of the established connections are still allowed
+	_, err := server.Request(ctx, newMonitoredRequest("0"))
+	require.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- drainServer.Drain(ctx)
+	}()
+
+	// The connections are moved away by the clients, the next batch waits for the interval
+	require.Eventually(t, func() bool { return counter.Closes() == 2 }, time.Second, time.Millisecond*10)
+	require.Never(t, func() bool { return counter.Closes() > 2 }, time.Millisecond*100, time.Millisecond*10)
+
+	require.Eventually(t, func() bool {
+		clockMock.Add(time.Minute)
+		return counter.Closes() == 5
+	}, time.Second, time.Millisecond*10)
+	require.NoError(t, <-errCh)
+}
+
+func TestDrainServer_ReselectTimeout(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	counter := new(count.Server)
+	drainServer := drain.NewServer(
+		drain.WithBatchInterval(0),
+		drain.WithReselectTimeout(time.Minute),
+	)
+	var monitorServer networkservice.MonitorConnectionServer
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		monitor.NewServer(ctx, &monitorServer),
+		drainServer,
+		counter,
+	)
+
+	monitorClient, err := adapters.NewMonitorServerToClient(monitorServer).MonitorConnections(ctx, &networkservice.MonitorScopeSelector{
+		PathSegments: []*networkservice.PathSegment{{Name: "nsc"}},
+	})
+	require.NoError(t, err)
+	_, err = monitorClient.Recv()
+	require.NoError(t, err)
+
+	_, err = server.Request(ctx, newMonitoredRequest("0"))
+	require.NoError(t, err)
+	_, err = monitorClient.Recv()
+	require.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- drainServer.Drain(ctx)
+	}()
+
+	// The client doesn't move the connection away, it is kept till the timeout
+	event, err := monitorClient.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.State_RESELECT_REQUESTED, event.GetConnections()["0"].GetState())
+	require.Equal(t, 0, counter.Closes())
+
+	require.Eventually(t, func() bool {
+		clockMock.Add(time.Minute)
+		return counter.Closes() == 1
+	}, time.Second, time.Millisecond*10)
+	require.NoError(t, <-errCh)
+}
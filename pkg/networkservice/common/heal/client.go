@@ -36,6 +36,7 @@ type healClient struct {
 	livenessCheck         LivenessCheck
 	livenessCheckInterval time.Duration
 	livenessCheckTimeout  time.Duration
+	makeBeforeBreak       bool
 }
 
 // NewClient - returns a new heal client chain element
@@ -47,11 +48,15 @@ func NewClient(chainCtx context.Context, opts ...Option) networkservice.NetworkS
 	for _, opt := range opts {
 		opt(o)
 	}
+	if o.makeBeforeBreak && o.livenessCheck == nil {
+		panic("make-before-break requires a liveness check")
+	}
 	return &healClient{
 		chainCtx:              chainCtx,
 		livenessCheck:         o.livenessCheck,
 		livenessCheckInterval: o.livenessCheckInterval,
 		livenessCheckTimeout:  o.livenessCheckTimeout,
+		makeBeforeBreak:       o.makeBeforeBreak,
 	}
 }
 
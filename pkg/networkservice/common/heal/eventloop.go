@@ -28,6 +28,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/history"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
 )
 
@@ -38,6 +39,7 @@ type eventLoop struct {
 	chainCtx         context.Context
 	conn             *networkservice.Connection
 	eventFactory     begin.EventFactory
+	history          *history.History
 	client           networkservice.MonitorConnection_MonitorConnectionsClient
 	logger           log.Logger
 	healingStartedCh chan bool
@@ -79,6 +81,7 @@ func newEventLoop(ctx context.Context, cc grpc.ClientConnInterface, conn *networ
 		chainCtx:         ctx,
 		conn:             conn,
 		eventFactory:     ev,
+		history:          history.Load(ctx, true),
 		client:           newClientFilter(client, conn, logger),
 		logger:           logger,
 		healingStartedCh: make(chan bool, 1),
@@ -139,6 +142,7 @@ func (cev *eventLoop) waitForEvents() (canceled, reselect bool) {
 			return true, false
 		}
 		cev.logger.Warnf("Control plane is down")
+		cev.history.Add(cev.chainCtx, history.HealStarted, "control plane is down")
 		cev.healingStartedCh <- true
 		// use reselect if data plane monitoring isn't available
 		return false, dataPlaneCh == nil
@@ -148,6 +152,7 @@ func (cev *eventLoop) waitForEvents() (canceled, reselect bool) {
 			return true, false
 		}
 		cev.logger.Warnf("Data plane is down")
+		cev.history.Add(cev.chainCtx, history.HealStarted, "data plane is down")
 		cev.healingStartedCh <- true
 		return false, true
 	case <-cev.chainCtx.Done():
@@ -177,6 +182,7 @@ func (cev *eventLoop) eventLoop() {
 				deadlineCtx, deadlineCancel := context.WithDeadline(cev.chainCtx, time.Now().Add(cev.heal.livenessCheckTimeout))
 				if !cev.heal.livenessCheck(deadlineCtx, cev.conn) {
 					cev.logger.Warnf("Data plane is down")
+					cev.history.Add(cev.chainCtx, history.HealStarted, "data plane is down")
 					reselect = true
 				}
 				deadlineCancel()
@@ -186,6 +192,9 @@ func (cev *eventLoop) eventLoop() {
 			if reselect {
 				cev.logger.Debugf("Reconnect with reselect")
 				options = append(options, begin.WithReselect())
+				if cev.heal.makeBeforeBreak {
+					options = append(options, begin.WithMakeBeforeBreak(cev.newConnectionLivenessCheck()))
+				}
 			}
 			err := <-cev.eventFactory.Request(options...)
 			if err == nil {
@@ -196,6 +205,17 @@ func (cev *eventLoop) eventLoop() {
 	}
 }
 
+func (cev *eventLoop) newConnectionLivenessCheck() begin.LivenessCheck {
+	if cev.heal.livenessCheck == nil {
+		return nil
+	}
+	return func(ctx context.Context, conn *networkservice.Connection) bool {
+		deadlineCtx, deadlineCancel := context.WithDeadline(ctx, time.Now().Add(cev.heal.livenessCheckTimeout))
+		defer deadlineCancel()
+		return cev.heal.livenessCheck(deadlineCtx, conn)
+	}
+}
+
 func (cev *eventLoop) monitorDataPlane() <-chan struct{} {
 	if cev.heal.livenessCheck == nil {
 		return nil
@@ -35,6 +35,7 @@ type options struct {
 	livenessCheck         LivenessCheck
 	livenessCheckInterval time.Duration
 	livenessCheckTimeout  time.Duration
+	makeBeforeBreak       bool
 }
 
 // Option - option for heal.NewClient() chain element
@@ -60,3 +61,11 @@ func WithLivenessCheckTimeout(livenessCheckTimeout time.Duration) Option {
 		o.livenessCheckTimeout = livenessCheckTimeout
 	}
 }
+
+// WithMakeBeforeBreak - enables make-before-break reselect: the old connection is closed only after the new one is
+// established to a different NSE and passes the liveness check. Requires WithLivenessCheck.
+func WithMakeBeforeBreak() Option {
+	return func(o *options) {
+		o.makeBeforeBreak = true
+	}
+}
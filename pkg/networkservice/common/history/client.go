@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+)
+
+type historyClient struct {
+	*retention
+}
+
+// NewClient - returns a new history client chain element. Should be placed right after metadata.
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{
+		retention: defaultRetention,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &historyClient{
+		retention: &retention{duration: o.retention},
+	}
+}
+
+func (c *historyClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	h := c.restore(ctx, true, request.GetConnection().GetId())
+	beforeRequest(ctx, h, request)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+
+	afterRequest(ctx, h, conn, err)
+	return conn, err
+}
+
+func (c *historyClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	h := Load(ctx, true)
+	h.Add(ctx, Closed, "network service endpoint %s", conn.GetNetworkServiceEndpointName())
+	c.retain(ctx, conn.GetId(), h)
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
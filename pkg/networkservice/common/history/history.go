@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+)
+
+// MaxEvents is the max number of events kept per connection. Older events are dropped.
+const MaxEvents = 32
+
+// EventType is a connection lifecycle event type
+type EventType string
+
+const (
+	// Requested - the connection has been established
+	Requested EventType = "requested"
+	// Refreshed - the connection has been refreshed
+	Refreshed EventType = "refreshed"
+	// RequestFailed - the connection Request has failed
+	RequestFailed EventType = "request_failed"
+	// Reselected - the connection has been requested with reselect
+	Reselected EventType = "reselected"
+	// EndpointChanged - the connection has moved to another NSE
+	EndpointChanged EventType = "endpoint_changed"
+	// RefreshFailed - the refresh of the connection has failed
+	RefreshFailed EventType = "refresh_failed"
+	// HealStarted - heal has detected a failure of the connection
+	HealStarted EventType = "heal_started"
+	// Timeout - the connection has expired
+	Timeout EventType = "timeout"
+	// Evicted - the connection has been closed by some chain element or by admin
+	Evicted EventType = "evicted"
+	// Closed - the connection has been closed
+	Closed EventType = "closed"
+)
+
+// Event is a connection lifecycle event
+type Event struct {
+	Time    time.Time
+	Type    EventType
+	Message string
+	// Count is the number of the same consecutive events collapsed into the one, Time is the time of the last of them
+	Count int
+}
+
+// History is a bounded per connection event history
+type History struct {
+	mu          sync.Mutex
+	events      []Event
+	established bool
+	endpoint    string
+}
+
+// Add adds a new event to the history. Consecutive Refreshed events are collapsed into the one. Nil History ignores
+// events.
+func (h *History) Add(ctx context.Context, eventType EventType, format string, args ...interface{}) {
+	if h == nil {
+		return
+	}
+
+	now := clock.FromContext(ctx).Now()
+	message := fmt.Sprintf(format, args...)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if n := len(h.events); n > 0 && eventType == Refreshed && h.events[n-1].Type == Refreshed {
+		h.events[n-1].Time = now
+		h.events[n-1].Message = message
+		h.events[n-1].Count++
+		return
+	}
+
+	h.events = append(h.events, Event{
+		Time:    now,
+		Type:    eventType,
+		Message: message,
+		Count:   1,
+	})
+	if len(h.events) > MaxEvents {
+		h.events = append(h.events[:0:0], h.events[len(h.events)-MaxEvents:]...)
+	}
+}
+
+// Events returns a copy of the history events, oldest first
+func (h *History) Events() []Event {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]Event(nil), h.events...)
+}
+
+// establish marks the connection established with the NSE and returns the previous NSE name, ok is false if the
+// connection was not established before
+func (h *History) establish(endpoint string) (prev string, ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	prev, ok = h.endpoint, h.established
+	h.endpoint, h.established = endpoint, true
+	return prev, ok
+}
+
+func (h *History) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.established = false
+}
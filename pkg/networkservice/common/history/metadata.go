@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+)
+
+type key struct{}
+
+// Load returns the connection History stored in per Connection.Id metadata, creating it if needed
+func Load(ctx context.Context, isClient bool) *History {
+	rawValue, _ := metadata.Map(ctx, isClient).LoadOrStore(key{}, new(History))
+	return rawValue.(*History)
+}
+
+// retention keeps the histories of the closed connections for some time, so that a connection closed and requested
+// again with the same ID (e.g. on reselect) continues its history
+type retention struct {
+	duration  time.Duration
+	mu        sync.Mutex
+	histories map[string]*History
+}
+
+// restore stores the retained History of the connection to metadata if there is no History yet
+func (r *retention) restore(ctx context.Context, isClient bool, connID string) *History {
+	r.mu.Lock()
+	h, ok := r.histories[connID]
+	delete(r.histories, connID)
+	r.mu.Unlock()
+
+	if !ok {
+		return Load(ctx, isClient)
+	}
+	rawValue, _ := metadata.Map(ctx, isClient).LoadOrStore(key{}, h)
+	return rawValue.(*History)
+}
+
+func (r *retention) retain(ctx context.Context, connID string, h *History) {
+	h.close()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.histories == nil {
+		r.histories = make(map[string]*History)
+	}
+	r.histories[connID] = h
+	clock.FromContext(ctx).AfterFunc(r.duration, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if r.histories[connID] == h {
+			delete(r.histories, connID)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import "time"
+
+const defaultRetention = time.Minute
+
+type options struct {
+	retention time.Duration
+}
+
+// Option is an option pattern for NewServer, NewClient
+type Option func(*options)
+
+// WithRetention sets how long the history of the closed connection is kept to be continued if the connection is
+// requested again with the same ID. Default: 1m
+func WithRetention(retention time.Duration) Option {
+	return func(o *options) {
+		o.retention = retention
+	}
+}
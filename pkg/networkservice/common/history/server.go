@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package history provides chain elements recording a bounded per connection lifecycle event history in metadata.
+//
+// The history elements record requests, refreshes, reselects, NSE changes and closes. Other chain elements making
+// lifecycle decisions (heal, refresh, timeout, netsvcmonitor, admin) add their events with the reason to the same
+// History loaded with Load.
+package history
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+)
+
+type historyServer struct {
+	*retention
+}
+
+// NewServer - returns a new history server chain element. Should be placed right after metadata.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		retention: defaultRetention,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &historyServer{
+		retention: &retention{duration: o.retention},
+	}
+}
+
+func (s *historyServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	h := s.restore(ctx, false, request.GetConnection().GetId())
+	beforeRequest(ctx, h, request)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+
+	afterRequest(ctx, h, conn, err)
+	return conn, err
+}
+
+func (s *historyServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	h := Load(ctx, false)
+	h.Add(ctx, Closed, "network service endpoint %s", conn.GetNetworkServiceEndpointName())
+	s.retain(ctx, conn.GetId(), h)
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func beforeRequest(ctx context.Context, h *History, request *networkservice.NetworkServiceRequest) {
+	if request.GetConnection().GetState() == networkservice.State_RESELECT_REQUESTED {
+		h.Add(ctx, Reselected, "reselect requested")
+	}
+}
+
+func afterRequest(ctx context.Context, h *History, conn *networkservice.Connection, err error) {
+	if err != nil {
+		h.Add(ctx, RequestFailed, "%s", err.Error())
+		return
+	}
+
+	endpoint := conn.GetNetworkServiceEndpointName()
+	prev, established := h.establish(endpoint)
+	if established {
+		h.Add(ctx, Refreshed, "network service endpoint %s", endpoint)
+	} else {
+		h.Add(ctx, Requested, "network service %s, network service endpoint %s", conn.GetNetworkService(), endpoint)
+	}
+	if prev != "" && prev != endpoint {
+		h.Add(ctx, EndpointChanged, "network service endpoint %s -> %s", prev, endpoint)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/history"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+)
+
+func eventTypes(h *history.History) []history.EventType {
+	var rv []history.EventType
+	for _, event := range h.Events() {
+		rv = append(rv, event.Type)
+	}
+	return rv
+}
+
+func TestHistoryServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var h *history.History
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		history.NewServer(),
+		checkcontext.NewServer(t, func(_ *testing.T, ctx context.Context) {
+			h = history.Load(ctx, false)
+		}),
+		injecterror.NewServer(
+			injecterror.WithRequestErrorTimes(4),
+			injecterror.WithCloseErrorTimes(),
+		),
+	)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:                         "id",
+			NetworkService:             "ns",
+			NetworkServiceEndpointName: "nse-1",
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		conn, err := server.Request(ctx, request.Clone())
+		require.NoError(t, err)
+		request.Connection = conn
+	}
+	require.Equal(t, []history.EventType{history.Requested, history.Refreshed}, eventTypes(h))
+	require.Equal(t, 2, h.Events()[1].Count)
+
+	// begin closes the connection on reselect, the history is continued
+	request.Connection.NetworkServiceEndpointName = "nse-2"
+	request.Connection.State = networkservice.State_RESELECT_REQUESTED
+	conn, err := server.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	require.Equal(t, "nse-2", conn.GetNetworkServiceEndpointName())
+	request.Connection = conn
+	request.Connection.State = networkservice.State_UP
+
+	_, err = server.Request(ctx, request.Clone())
+	require.Error(t, err)
+
+	_, err = server.Close(ctx, conn)
+	require.NoError(t, err)
+
+	require.Equal(t, []history.EventType{
+		history.Requested,
+		history.Refreshed,
+		history.Closed,
+		history.Reselected,
+		history.Requested,
+		history.EndpointChanged,
+		history.RequestFailed,
+		history.Closed,
+	}, eventTypes(h))
+}
+
+func TestHistory_Bounded(t *testing.T) {
+	ctx := context.Background()
+
+	h := new(history.History)
+	for i := 0; i < history.MaxEvents*2; i++ {
+		h.Add(ctx, history.RequestFailed, "error %d", i)
+	}
+
+	events := h.Events()
+	require.Len(t, events, history.MaxEvents)
+	require.Equal(t, "error 32", events[0].Message)
+	require.Equal(t, "error 63", events[len(events)-1].Message)
+
+	var nilHistory *history.History
+	nilHistory.Add(ctx, history.Closed, "%v", errors.New("ignored"))
+	require.Empty(t, nilHistory.Events())
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/postpone"
+)
+
+type ipsecClient struct {
+	tunnelIP net.IP
+	source   x509svid.Source
+	*ipsecOptions
+}
+
+// NewClient - set the SrcIP, SrcPort, identity and the offered IKE/ESP proposals for the ipsec mechanism
+//   - tunnelIP - local tunnel endpoint
+//   - source - x509 SVID source the identity is derived from
+func NewClient(tunnelIP net.IP, source x509svid.Source, options ...Option) networkservice.NetworkServiceClient {
+	return &ipsecClient{
+		tunnelIP:     tunnelIP,
+		source:       source,
+		ipsecOptions: newOptions(options...),
+	}
+}
+
+func (c *ipsecClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	mechanisms := request.GetMechanismPreferences()
+	if m := request.GetConnection().GetMechanism(); m != nil {
+		mechanisms = append([]*networkservice.Mechanism{m}, mechanisms...)
+	}
+
+	var updated bool
+	for _, m := range mechanisms {
+		mech := ipsec.ToMechanism(m)
+		if mech == nil {
+			continue
+		}
+		id, publicKey, err := identity(c.source)
+		if err != nil {
+			return nil, err
+		}
+		mech.SetSrcIP(c.tunnelIP).SetSrcPort(c.tunnelPort).SetSrcPublicKey(publicKey)
+		mech.GetParameters()[SrcIdentity] = id
+		mech.GetParameters()[IKEProposals] = join(c.ikeProposals)
+		mech.GetParameters()[ESPProposals] = join(c.espProposals)
+		updated = true
+
+		log.FromContext(ctx).
+			WithField("ipsecClient", "request").
+			WithField("mechSrcIp", mech.SrcIP()).
+			WithField("mechSrcIdentity", id).
+			Debugf("set mechanism src")
+	}
+	if !updated {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.validate(ipsec.ToMechanism(conn.GetMechanism())); err != nil {
+		if !load(ctx, metadata.IsClient(c)) {
+			closeCtx, cancelClose := postponeCtxFunc()
+			defer cancelClose()
+
+			if _, closeErr := next.Client(ctx).Close(closeCtx, conn, opts...); closeErr != nil {
+				err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+			}
+		}
+		return nil, err
+	}
+
+	store(ctx, metadata.IsClient(c))
+	return conn, nil
+}
+
+func (c *ipsecClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	del(ctx, metadata.IsClient(c))
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// validate checks the server answer: the selected proposals must be among the offered ones and the destination
+// identity must be set
+func (c *ipsecClient) validate(mech *ipsec.Mechanism) error {
+	if mech == nil {
+		// Another mechanism has been selected
+		return nil
+	}
+	params := mech.GetParameters()
+	if !contains(c.ikeProposals, params[IKEProposal]) {
+		return errors.Errorf("ipsec mechanism has unexpected IKE proposal: %q", params[IKEProposal])
+	}
+	if !contains(c.espProposals, params[ESPProposal]) {
+		return errors.Errorf("ipsec mechanism has unexpected ESP proposal: %q", params[ESPProposal])
+	}
+	if params[DstIdentity] == "" || mech.DstPublicKey() == "" {
+		return errors.New("ipsec mechanism has no destination identity")
+	}
+	return nil
+}
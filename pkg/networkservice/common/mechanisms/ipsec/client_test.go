@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	ipsecmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/ipsec"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+)
+
+type svidSource struct {
+	svid *x509svid.SVID
+}
+
+func (s *svidSource) GetX509SVID() (*x509svid.SVID, error) {
+	return s.svid, nil
+}
+
+func newSource(t *testing.T, spiffeID string) *svidSource {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	id := spiffeid.RequireFromString(spiffeID)
+	uri, err := url.Parse(spiffeID)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{uri},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &svidSource{
+		svid: &x509svid.SVID{
+			ID:           id,
+			Certificates: []*x509.Certificate{cert},
+			PrivateKey:   priv,
+		},
+	}
+}
+
+func publicKey(t *testing.T, source *svidSource) string {
+	der, err := x509.MarshalPKIXPublicKey(source.svid.Certificates[0].PublicKey)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+func newRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{
+				Cls:  cls.REMOTE,
+				Type: ipsecmech.MECHANISM,
+			},
+		},
+	}
+}
+
+func TestIPSec_Negotiation(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	clientSource := newSource(t, "spiffe://example.org/forwarder-1")
+	serverSource := newSource(t, "spiffe://example.org/forwarder-2")
+
+	client := next.NewNetworkServiceClient(
+		metadata.NewClient(),
+		ipsec.NewClient(net.ParseIP("192.0.2.1"), clientSource,
+			ipsec.WithIKEProposals("aes128gcm16-prfsha256-ecp256", "aes256gcm16-prfsha384-ecp384"),
+			ipsec.WithESPProposals("aes256gcm16-ecp384", "aes128gcm16-ecp256"),
+		),
+		adapters.NewServerToClient(next.NewNetworkServiceServer(
+			metadata.NewServer(),
+			mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
+				ipsecmech.MECHANISM: ipsec.NewServer(net.ParseIP("192.0.2.2"), serverSource,
+					ipsec.WithTunnelPort(4500),
+					ipsec.WithIKEProposals("aes256gcm16-prfsha384-ecp384"),
+				),
+			}),
+		)),
+	)
+
+	conn, err := client.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+
+	mech := ipsecmech.ToMechanism(conn.GetMechanism())
+	require.NotNil(t, mech)
+	require.Equal(t, "192.0.2.1", mech.SrcIP().String())
+	require.Equal(t, "192.0.2.2", mech.DstIP().String())
+	require.Equal(t, uint16(500), mech.SrcPort())
+	require.Equal(t, uint16(4500), mech.DstPort())
+	require.Equal(t, publicKey(t, clientSource), mech.SrcPublicKey())
+	require.Equal(t, publicKey(t, serverSource), mech.DstPublicKey())
+
+	params := mech.GetParameters()
+	require.Equal(t, "spiffe://example.org/forwarder-1", params[ipsec.SrcIdentity])
+	require.Equal(t, "spiffe://example.org/forwarder-2", params[ipsec.DstIdentity])
+	require.Equal(t, "aes256gcm16-prfsha384-ecp384", params[ipsec.IKEProposal])
+	require.Equal(t, "aes256gcm16-ecp384", params[ipsec.ESPProposal])
+
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+}
+
+func TestIPSec_NoDstIdentity(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	var closed bool
+	client := next.NewNetworkServiceClient(
+		metadata.NewClient(),
+		ipsec.NewClient(net.ParseIP("192.0.2.1"), newSource(t, "spiffe://example.org/forwarder-1")),
+		adapters.NewServerToClient(&closeServer{closed: &closed}),
+	)
+
+	request := newRequest()
+	request.GetConnection().Mechanism = request.GetMechanismPreferences()[0]
+
+	_, err := client.Request(context.Background(), request)
+	require.Error(t, err)
+	require.True(t, closed)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+// identity returns the SPIFFE ID and the base64 encoded PKIX public key of the current SVID
+func identity(source x509svid.Source) (id, publicKey string, err error) {
+	svid, err := source.GetX509SVID()
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to get x509 SVID")
+	}
+	if len(svid.Certificates) == 0 {
+		return "", "", errors.New("x509 SVID has no certificates")
+	}
+	der, err := x509.MarshalPKIXPublicKey(svid.Certificates[0].PublicKey)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to marshal x509 SVID public key")
+	}
+	return svid.ID.String(), base64.StdEncoding.EncodeToString(der), nil
+}
+
+func join(proposals []string) string {
+	return strings.Join(proposals, ",")
+}
+
+func split(proposals string) []string {
+	var result []string
+	for _, proposal := range strings.Split(proposals, ",") {
+		if proposal = strings.TrimSpace(proposal); proposal != "" {
+			result = append(result, proposal)
+		}
+	}
+	return result
+}
+
+// selectProposal returns the first offered proposal which is allowed
+func selectProposal(offered, allowed []string) (string, bool) {
+	for _, proposal := range offered {
+		if contains(allowed, proposal) {
+			return proposal, true
+		}
+	}
+	return "", false
+}
+
+func contains(proposals []string, proposal string) bool {
+	for _, p := range proposals {
+		if p == proposal {
+			return true
+		}
+	}
+	return false
+}
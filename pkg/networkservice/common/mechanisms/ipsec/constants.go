@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+const (
+	ikePort = 500
+
+	// IKEProposals - comma separated IKE proposals offered by the client, most preferred first
+	IKEProposals = "ike_proposals"
+	// ESPProposals - comma separated ESP proposals offered by the client, most preferred first
+	ESPProposals = "esp_proposals"
+	// IKEProposal - IKE proposal selected by the server
+	IKEProposal = "ike_proposal"
+	// ESPProposal - ESP proposal selected by the server
+	ESPProposal = "esp_proposal"
+	// SrcIdentity - SPIFFE ID of the source
+	SrcIdentity = "src_identity"
+	// DstIdentity - SPIFFE ID of the destination
+	DstIdentity = "dst_identity"
+)
+
+var (
+	defaultIKEProposals = []string{
+		"aes256gcm16-prfsha384-ecp384",
+		"aes128gcm16-prfsha256-ecp256",
+		"aes256-sha256-modp2048",
+	}
+	defaultESPProposals = []string{
+		"aes256gcm16-ecp384",
+		"aes128gcm16-ecp256",
+		"aes256-sha256-modp2048",
+	}
+)
@@ -0,0 +1,20 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipsec provides networkservice.NetworkService{Client,Server} chain elements negotiating the IPsec (IKEv2)
+// mechanism: tunnel endpoints, IKE/ESP proposals selected from the allowed cipher-suites and identities derived from
+// the SPIFFE X.509 SVIDs.
+package ipsec
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+// Option is an option pattern for ipsec server/client
+type Option func(o *ipsecOptions)
+
+// WithTunnelPort sets IKE port
+func WithTunnelPort(tunnelPort uint16) Option {
+	return func(o *ipsecOptions) {
+		if tunnelPort != 0 {
+			o.tunnelPort = tunnelPort
+		}
+	}
+}
+
+// WithIKEProposals sets the allowed IKE proposals, most preferred first
+func WithIKEProposals(proposals ...string) Option {
+	return func(o *ipsecOptions) {
+		if len(proposals) > 0 {
+			o.ikeProposals = proposals
+		}
+	}
+}
+
+// WithESPProposals sets the allowed ESP proposals, most preferred first
+func WithESPProposals(proposals ...string) Option {
+	return func(o *ipsecOptions) {
+		if len(proposals) > 0 {
+			o.espProposals = proposals
+		}
+	}
+}
+
+type ipsecOptions struct {
+	tunnelPort   uint16
+	ikeProposals []string
+	espProposals []string
+}
+
+func newOptions(options ...Option) *ipsecOptions {
+	opts := &ipsecOptions{
+		tunnelPort:   ikePort,
+		ikeProposals: defaultIKEProposals,
+		espProposals: defaultESPProposals,
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+	return opts
+}
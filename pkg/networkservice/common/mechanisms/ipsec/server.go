@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+type ipsecServer struct {
+	tunnelIP net.IP
+	source   x509svid.Source
+	*ipsecOptions
+}
+
+// NewServer - set the DstIP, DstPort, identity and select the IKE/ESP proposals for the ipsec mechanism
+//   - tunnelIP - local tunnel endpoint
+//   - source - x509 SVID source the identity is derived from
+func NewServer(tunnelIP net.IP, source x509svid.Source, options ...Option) networkservice.NetworkServiceServer {
+	return &ipsecServer{
+		tunnelIP:     tunnelIP,
+		source:       source,
+		ipsecOptions: newOptions(options...),
+	}
+}
+
+func (s *ipsecServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	logger := log.FromContext(ctx).WithField("ipsecServer", "request")
+
+	mech := ipsec.ToMechanism(request.GetConnection().GetMechanism())
+	if mech == nil {
+		logger.Debugf("mechanism is not ipsec")
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	params := mech.GetParameters()
+	if params[SrcIdentity] == "" || mech.SrcPublicKey() == "" {
+		return nil, errors.New("ipsec mechanism has no source identity")
+	}
+	ikeProposal, ok := selectProposal(split(params[IKEProposals]), s.ikeProposals)
+	if !ok {
+		return nil, errors.Errorf("no acceptable IKE proposal in %q", params[IKEProposals])
+	}
+	espProposal, ok := selectProposal(split(params[ESPProposals]), s.espProposals)
+	if !ok {
+		return nil, errors.Errorf("no acceptable ESP proposal in %q", params[ESPProposals])
+	}
+
+	id, publicKey, err := identity(s.source)
+	if err != nil {
+		return nil, err
+	}
+
+	mech.SetDstIP(s.tunnelIP).SetDstPort(s.tunnelPort).SetDstPublicKey(publicKey)
+	params[DstIdentity] = id
+	params[IKEProposal] = ikeProposal
+	params[ESPProposal] = espProposal
+
+	logger.WithField("mechanism.DstIP", mech.DstIP()).
+		WithField("mechanism.DstPort", mech.DstPort()).
+		WithField("ikeProposal", ikeProposal).
+		WithField("espProposal", espProposal).
+		Debugf("set mechanism dst")
+
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *ipsecServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
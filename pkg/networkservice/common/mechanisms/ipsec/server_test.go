@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	ipsecmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/ipsec"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+)
+
+type closeServer struct {
+	closed *bool
+}
+
+func (s *closeServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *closeServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	*s.closed = true
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func newServerRequest(t *testing.T, ikeProposals, espProposals string) *networkservice.NetworkServiceRequest {
+	request := newRequest()
+	request.GetConnection().Mechanism = &networkservice.Mechanism{
+		Cls:  cls.REMOTE,
+		Type: ipsecmech.MECHANISM,
+	}
+	clientSource := newSource(t, "spiffe://example.org/forwarder-1")
+	mech := ipsecmech.ToMechanism(request.GetConnection().GetMechanism()).
+		SetSrcIP(net.ParseIP("192.0.2.1")).
+		SetSrcPublicKey(publicKey(t, clientSource))
+	mech.GetParameters()[ipsec.SrcIdentity] = "spiffe://example.org/forwarder-1"
+	mech.GetParameters()[ipsec.IKEProposals] = ikeProposals
+	mech.GetParameters()[ipsec.ESPProposals] = espProposals
+	return request
+}
+
+func TestIPSecServer_ClientPreference(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := ipsec.NewServer(net.ParseIP("192.0.2.2"), newSource(t, "spiffe://example.org/forwarder-2"))
+
+	conn, err := server.Request(context.Background(), newServerRequest(t,
+		"unknown, aes256-sha256-modp2048,aes128gcm16-prfsha256-ecp256",
+		"aes128gcm16-ecp256,aes256gcm16-ecp384",
+	))
+	require.NoError(t, err)
+
+	params := conn.GetMechanism().GetParameters()
+	require.Equal(t, "aes256-sha256-modp2048", params[ipsec.IKEProposal])
+	require.Equal(t, "aes128gcm16-ecp256", params[ipsec.ESPProposal])
+	require.Equal(t, "spiffe://example.org/forwarder-2", params[ipsec.DstIdentity])
+	require.Equal(t, "192.0.2.2", ipsecmech.ToMechanism(conn.GetMechanism()).DstIP().String())
+}
+
+func TestIPSecServer_NoCommonProposal(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := ipsec.NewServer(net.ParseIP("192.0.2.2"), newSource(t, "spiffe://example.org/forwarder-2"),
+		ipsec.WithESPProposals("aes256gcm16-ecp384"),
+	)
+
+	_, err := server.Request(context.Background(), newServerRequest(t, "aes256gcm16-prfsha384-ecp384", "aes128gcm16-ecp256"))
+	require.Error(t, err)
+
+	_, err = server.Request(context.Background(), newServerRequest(t, "3des-md5-modp1024", "aes256gcm16-ecp384"))
+	require.Error(t, err)
+}
+
+func TestIPSecServer_NonIPSec(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.LOCAL,
+				Type: kernel.MECHANISM,
+			},
+		},
+	}
+	server := ipsec.NewServer(net.ParseIP("192.0.2.2"), newSource(t, "spiffe://example.org/forwarder-2"))
+	conn, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Nil(t, ipsecmech.ToMechanism(conn.GetMechanism()))
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlan
+
+import (
+	"context"
+	"sync"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/pkg/errors"
+)
+
+const (
+	minVLANID = 1
+	maxVLANID = 4094
+)
+
+// Allocator allocates VLAN IDs per trunk (physical network)
+type Allocator interface {
+	// Allocate reserves a VLAN ID on the trunk. If vlanID is not 0, exactly this ID is reserved (recovery of an existing
+	// ID) or an error is returned if it is not free. Otherwise any free ID from the trunk range is reserved.
+	Allocate(ctx context.Context, trunk string, vlanID uint32) (uint32, error)
+	// Release frees the VLAN ID on the trunk
+	Release(ctx context.Context, trunk string, vlanID uint32) error
+}
+
+// Range is a range of VLAN IDs [Min, Max] available on the trunk, an empty Trunk sets the default range
+type Range struct {
+	Trunk string
+	Min   uint32
+	Max   uint32
+}
+
+type localAllocator struct {
+	ranges    map[string]Range
+	freeVLANs map[string]*roaring.Bitmap
+	lock      sync.Mutex
+}
+
+// NewLocalAllocator - returns an Allocator keeping the free VLAN IDs in a local bitmap per trunk. The default range
+// is 1-4094, it can be overridden per trunk with ranges.
+func NewLocalAllocator(ranges ...Range) Allocator {
+	a := &localAllocator{
+		ranges: map[string]Range{
+			"": {Min: minVLANID, Max: maxVLANID},
+		},
+		freeVLANs: make(map[string]*roaring.Bitmap),
+	}
+	for _, r := range ranges {
+		if r.Min < minVLANID {
+			r.Min = minVLANID
+		}
+		if r.Max > maxVLANID {
+			r.Max = maxVLANID
+		}
+		a.ranges[r.Trunk] = r
+	}
+	return a
+}
+
+func (a *localAllocator) Allocate(_ context.Context, trunk string, vlanID uint32) (uint32, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	freeVLANs := a.trunkVLANs(trunk)
+	switch {
+	case vlanID != 0:
+		if !freeVLANs.Contains(vlanID) {
+			return 0, errors.Errorf("vlan id %d is not available for allocation on trunk %q", vlanID, trunk)
+		}
+	case freeVLANs.IsEmpty():
+		return 0, errors.Errorf("vlan id not available for allocation on trunk %q", trunk)
+	default:
+		vlanID = freeVLANs.Minimum()
+	}
+	freeVLANs.Remove(vlanID)
+	return vlanID, nil
+}
+
+func (a *localAllocator) Release(_ context.Context, trunk string, vlanID uint32) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	r := a.trunkRange(trunk)
+	if vlanID < r.Min || vlanID > r.Max {
+		return errors.Errorf("vlan id %d is out of range of trunk %q", vlanID, trunk)
+	}
+	a.trunkVLANs(trunk).Add(vlanID)
+	return nil
+}
+
+func (a *localAllocator) trunkRange(trunk string) Range {
+	if r, ok := a.ranges[trunk]; ok {
+		return r
+	}
+	return a.ranges[""]
+}
+
+func (a *localAllocator) trunkVLANs(trunk string) *roaring.Bitmap {
+	freeVLANs, ok := a.freeVLANs[trunk]
+	if !ok {
+		r := a.trunkRange(trunk)
+		freeVLANs = roaring.New()
+		if r.Min <= r.Max {
+			freeVLANs.AddRange(uint64(r.Min), uint64(r.Max)+1)
+		}
+		a.freeVLANs[trunk] = freeVLANs
+	}
+	return freeVLANs
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlan_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/kernel/vlan"
+)
+
+func TestLocalAllocator(t *testing.T) {
+	ctx := context.Background()
+	allocator := vlan.NewLocalAllocator(vlan.Range{Trunk: "trunk-1", Min: 100, Max: 101})
+
+	// Default range
+	vlanID, err := allocator.Allocate(ctx, "", 0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), vlanID)
+
+	// Recovery
+	vlanID, err = allocator.Allocate(ctx, "", 4094)
+	require.NoError(t, err)
+	require.Equal(t, uint32(4094), vlanID)
+
+	// Already allocated ID is not recovered twice
+	_, err = allocator.Allocate(ctx, "", 4094)
+	require.Error(t, err)
+
+	// Trunk range
+	vlanID, err = allocator.Allocate(ctx, "trunk-1", 0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID)
+
+	// ID out of the trunk range is not available
+	_, err = allocator.Allocate(ctx, "trunk-1", 5)
+	require.Error(t, err)
+
+	vlanID, err = allocator.Allocate(ctx, "trunk-1", 0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(101), vlanID)
+
+	_, err = allocator.Allocate(ctx, "trunk-1", 0)
+	require.Error(t, err)
+
+	require.NoError(t, allocator.Release(ctx, "trunk-1", 100))
+	require.Error(t, allocator.Release(ctx, "trunk-1", 1))
+
+	vlanID, err = allocator.Allocate(ctx, "trunk-1", 0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID)
+
+	// Trunks without a range use the default one independently
+	vlanID, err = allocator.Allocate(ctx, "trunk-2", 0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), vlanID)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlan
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type options struct {
+	allocator Allocator
+	trunkFunc func(conn *networkservice.Connection) string
+}
+
+// Option is an option pattern for vlanServer
+type Option func(o *options)
+
+// WithAllocator sets the VLAN ID allocator, a local one (NewLocalAllocator) is used by default
+func WithAllocator(allocator Allocator) Option {
+	return func(o *options) {
+		o.allocator = allocator
+	}
+}
+
+// WithTrunkFunc sets the function returning the trunk (physical network) the connection VLAN ID is allocated on,
+// all connections share the default "" trunk by default
+func WithTrunkFunc(trunkFunc func(conn *networkservice.Connection) string) Option {
+	return func(o *options) {
+		o.trunkFunc = trunkFunc
+	}
+}
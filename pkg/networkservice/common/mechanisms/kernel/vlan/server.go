@@ -19,71 +19,92 @@
 //go:build linux
 // +build linux
 
-// Package vlan provides server chain element setting vlan id on the kernel mechanism
 package vlan
 
 import (
 	"context"
-	"sync"
 
-	"github.com/pkg/errors"
-
-	"github.com/RoaringBitmap/roaring"
+	"github.com/edwarnicke/genericsync"
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	kernelmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
 )
 
+type vlanInfo struct {
+	trunk  string
+	vlanID uint32
+}
+
 type vlanServer struct {
-	freeVLANs *roaring.Bitmap
-	lock      sync.Mutex
+	allocator Allocator
+	trunkFunc func(conn *networkservice.Connection) string
+
+	// This map stores allocated VLAN IDs by Connection.Id
+	genericsync.Map[string, *vlanInfo]
 }
 
 // NewServer - creates a NetworkServiceServer that requests a kernel interface with vlan parameter
-func NewServer() networkservice.NetworkServiceServer {
-	vlans := roaring.New()
-	vlans.AddRange(1, 4095)
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		trunkFunc: func(*networkservice.Connection) string { return "" },
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.allocator == nil {
+		o.allocator = NewLocalAllocator()
+	}
 	return &vlanServer{
-		freeVLANs: vlans,
+		allocator: o.allocator,
+		trunkFunc: o.trunkFunc,
 	}
 }
 
 func (m *vlanServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	mechanism := kernelmech.ToMechanism(request.GetConnection().GetMechanism())
-	isEstablished := true
-	if mechanism != nil && mechanism.SupportsVLAN() && mechanism.GetVLAN() == 0 {
-		m.lock.Lock()
-		if m.freeVLANs.IsEmpty() {
-			m.lock.Unlock()
-			return nil, errors.New("vlan id not available for allocation")
-		}
-		vlanID := m.freeVLANs.Minimum()
-		m.freeVLANs.Remove(vlanID)
-		m.lock.Unlock()
+	if mechanism == nil || !mechanism.SupportsVLAN() {
+		return next.Server(ctx).Request(ctx, request)
+	}
 
-		mechanism.SetVLAN(vlanID)
-		isEstablished = false
+	id := request.GetConnection().GetId()
+	info, isEstablished := m.Load(id)
+	if !isEstablished {
+		trunk := m.trunkFunc(request.GetConnection())
+		// The VLAN ID of an existing connection is recovered, the request fails if it is already taken
+		vlanID, err := m.allocator.Allocate(ctx, trunk, mechanism.GetVLAN())
+		if err != nil {
+			return nil, err
+		}
+		info = &vlanInfo{
+			trunk:  trunk,
+			vlanID: vlanID,
+		}
+		m.Store(id, info)
 	}
+	mechanism.SetVLAN(info.vlanID)
+
 	conn, err := next.Server(ctx).Request(ctx, request)
-	if err != nil && mechanism != nil && !isEstablished {
-		m.releaseVLANID(mechanism)
+	if err != nil && !isEstablished {
+		m.release(ctx, id)
 	}
 	return conn, err
 }
 
 func (m *vlanServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
-	mechanism := kernelmech.ToMechanism(conn.GetMechanism())
-	if mechanism != nil && mechanism.SupportsVLAN() && mechanism.GetVLAN() > 0 {
-		m.releaseVLANID(mechanism)
-	}
+	m.release(ctx, conn.GetId())
 	return next.Server(ctx).Close(ctx, conn)
 }
 
-func (m *vlanServer) releaseVLANID(mechanism *kernelmech.Mechanism) {
-	m.lock.Lock()
-	defer m.lock.Unlock()
-	m.freeVLANs.Add(mechanism.GetVLAN())
+func (m *vlanServer) release(ctx context.Context, id string) {
+	info, ok := m.LoadAndDelete(id)
+	if !ok {
+		return
+	}
+	if err := m.allocator.Release(ctx, info.trunk, info.vlanID); err != nil {
+		log.FromContext(ctx).WithField("vlanServer", "release").Warnf("failed to release vlan id %d: %s", info.vlanID, err.Error())
+	}
 }
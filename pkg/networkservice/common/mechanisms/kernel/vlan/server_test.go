@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	kernelmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/kernel/vlan"
+)
+
+func newRequest(id, trunk string, vlanID uint32) *networkservice.NetworkServiceRequest {
+	mechanism := &networkservice.Mechanism{
+		Cls:  cls.LOCAL,
+		Type: kernelmech.MECHANISM,
+		Parameters: map[string]string{
+			kernelmech.SupportsVLAN: "true",
+		},
+	}
+	if vlanID != 0 {
+		kernelmech.ToMechanism(mechanism).SetVLAN(vlanID)
+	}
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:        id,
+			Mechanism: mechanism,
+			Labels: map[string]string{
+				"trunk": trunk,
+			},
+		},
+	}
+}
+
+func vlanID(conn *networkservice.Connection) uint32 {
+	return kernelmech.ToMechanism(conn.GetMechanism()).GetVLAN()
+}
+
+func TestVLANServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx := context.Background()
+	server := vlan.NewServer(
+		vlan.WithAllocator(vlan.NewLocalAllocator(vlan.Range{Trunk: "trunk-1", Min: 100, Max: 200})),
+		vlan.WithTrunkFunc(func(conn *networkservice.Connection) string {
+			return conn.GetLabels()["trunk"]
+		}),
+	)
+
+	conn1, err := server.Request(ctx, newRequest("id-1", "trunk-1", 0))
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID(conn1))
+
+	// Refresh keeps the VLAN ID
+	conn1, err = server.Request(ctx, &networkservice.NetworkServiceRequest{Connection: conn1})
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID(conn1))
+
+	// Existing VLAN ID is recovered
+	conn2, err := server.Request(ctx, newRequest("id-2", "trunk-1", 150))
+	require.NoError(t, err)
+	require.Equal(t, uint32(150), vlanID(conn2))
+
+	// Requested VLAN ID already taken is not replaced
+	_, err = server.Request(ctx, newRequest("id-5", "trunk-1", 150))
+	require.Error(t, err)
+
+	conn3, err := server.Request(ctx, newRequest("id-3", "trunk-2", 0))
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), vlanID(conn3))
+
+	_, err = server.Close(ctx, conn1)
+	require.NoError(t, err)
+
+	conn4, err := server.Request(ctx, newRequest("id-4", "trunk-1", 0))
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID(conn4))
+}
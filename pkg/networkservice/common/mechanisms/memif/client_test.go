@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package memif_test
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edwarnicke/grpcfd"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	memifmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/chains/client"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/memif"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkrequest"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+	"github.com/ljkiraly/sdk/pkg/tools/sandbox"
+)
+
+func TestMemif_SocketPassing(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tempDir := t.TempDir()
+	socketDir := filepath.Join(tempDir, "memif")
+	serveURL := &url.URL{Scheme: "unix", Path: filepath.Join(tempDir, "nse.sock")}
+
+	var socketPath string
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
+			memifmech.MECHANISM: memif.NewServer(memif.WithSocketDir(socketDir)),
+		}),
+		// Data plane creates the socket
+		checkrequest.NewServer(t, func(t *testing.T, request *networkservice.NetworkServiceRequest) {
+			socketURL, err := url.Parse(memifmech.ToMechanism(request.GetConnection().GetMechanism()).GetSocketFileURL())
+			require.NoError(t, err)
+			socketPath = socketURL.Path
+
+			file, err := os.Create(socketPath)
+			require.NoError(t, err)
+			require.NoError(t, file.Close())
+		}),
+	)
+
+	grpcServer := grpc.NewServer(grpc.Creds(grpcfd.TransportCredentials(insecure.NewCredentials())))
+	networkservice.RegisterNetworkServiceServer(grpcServer, server)
+	require.Len(t, grpcutils.ListenAndServe(ctx, serveURL, grpcServer), 0)
+
+	nsc := client.NewClient(
+		ctx,
+		client.WithClientURL(sandbox.CloneURL(serveURL)),
+		client.WithDialOptions(grpc.WithTransportCredentials(
+			grpcfd.TransportCredentials(insecure.NewCredentials())),
+		),
+		client.WithDialTimeout(time.Second),
+		client.WithoutRefresh(),
+		client.WithAdditionalFunctionality(memif.NewClient()),
+	)
+
+	conn, err := nsc.Request(ctx, &networkservice.NetworkServiceRequest{})
+	require.NoError(t, err)
+
+	mechanism := memifmech.ToMechanism(conn.GetMechanism())
+	require.NotNil(t, mechanism)
+	require.Equal(t, memif.RoleSlave, memif.Role(conn.GetMechanism(), true))
+
+	// The socket file has been received as an fd
+	socketURL, err := url.Parse(mechanism.GetSocketFileURL())
+	require.NoError(t, err)
+	require.Equal(t, memifmech.FileScheme, socketURL.Scheme)
+	require.NotEqual(t, socketPath, socketURL.Path)
+
+	received, err := os.Stat(socketURL.Path)
+	require.NoError(t, err)
+	origin, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	require.True(t, os.SameFile(received, origin))
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+
+	_, err = os.Stat(socketPath)
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memif
+
+import (
+	"net/url"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+const (
+	// ServerRole - memif role of the server side, the client side has the opposite one
+	ServerRole = "server_role"
+	// RoleMaster - memif master role
+	RoleMaster = "master"
+	// RoleSlave - memif slave role
+	RoleSlave = "slave"
+
+	defaultSocketDir = "/var/lib/networkservicemesh/memif"
+	socketNameLength = 10
+)
+
+var netNSURL = (&url.URL{Scheme: "file", Path: "/proc/thread-self/ns/net"}).String()
+
+// Role returns memif role of the client (isClient == true) or the server side of the mechanism
+func Role(mechanism *networkservice.Mechanism, isClient bool) string {
+	serverRole := mechanism.GetParameters()[ServerRole]
+	if serverRole == "" {
+		serverRole = RoleMaster
+	}
+	if !isClient {
+		return serverRole
+	}
+	if serverRole == RoleMaster {
+		return RoleSlave
+	}
+	return RoleMaster
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memif
+
+import (
+	"context"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+)
+
+type keySocket struct{}
+
+func storeSocket(ctx context.Context, isClient bool, socket string) {
+	metadata.Map(ctx, isClient).Store(keySocket{}, socket)
+}
+
+func loadSocket(ctx context.Context, isClient bool) (socket string, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(keySocket{})
+	if !ok {
+		return "", false
+	}
+	socket, ok = rawValue.(string)
+	return socket, ok
+}
+
+func loadAndDeleteSocket(ctx context.Context, isClient bool) (socket string, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(keySocket{})
+	if !ok {
+		return "", false
+	}
+	socket, ok = rawValue.(string)
+	return socket, ok
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memif
+
+type options struct {
+	socketDir string
+	abstract  bool
+	role      string
+}
+
+// Option is an option pattern for memif server
+type Option func(o *options)
+
+// WithSocketDir sets directory the per-connection socket files are generated in
+func WithSocketDir(socketDir string) Option {
+	return func(o *options) {
+		o.socketDir = socketDir
+	}
+}
+
+// WithAbstractSockets makes server to generate sockets in the abstract namespace of its netns instead of files
+func WithAbstractSockets() Option {
+	return func(o *options) {
+		o.abstract = true
+	}
+}
+
+// WithSlaveRole makes server side memif slave (it is master by default)
+func WithSlaveRole() Option {
+	return func(o *options) {
+		o.role = RoleSlave
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memif
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	memifmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/sendfd"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/nanoid"
+)
+
+type memifServer struct {
+	*options
+}
+
+// NewServer - creates a NetworkServiceServer that generates a per-connection memif socket, sets the memif role and
+// sends the socket file (or the netns of the abstract socket) to the client.
+// Note: the socket itself should be created by the data plane chain elements following this one.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		socketDir: defaultSocketDir,
+		role:      RoleMaster,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return chain.NewNetworkServiceServer(
+		sendfd.NewServer(),
+		&memifServer{
+			options: o,
+		},
+	)
+}
+
+func (m *memifServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	mechanism := memifmech.ToMechanism(request.GetConnection().GetMechanism())
+	if mechanism == nil {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	socket, loaded := loadSocket(ctx, metadata.IsClient(m))
+	if !loaded {
+		name, err := nanoid.GenerateString(socketNameLength)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate memif socket name")
+		}
+		if m.abstract {
+			socket = "memif-" + name
+		} else {
+			if err = os.MkdirAll(m.socketDir, 0o700); err != nil {
+				return nil, errors.Wrapf(err, "failed to create memif socket directory %s", m.socketDir)
+			}
+			socket = filepath.Join(m.socketDir, name+".sock")
+		}
+		storeSocket(ctx, metadata.IsClient(m), socket)
+	}
+
+	if m.abstract {
+		mechanism.SetSocketFilename(socket)
+		mechanism.SetNetNSURL(netNSURL)
+	} else {
+		mechanism.SetSocketFileURL((&url.URL{Scheme: memifmech.FileScheme, Path: socket}).String())
+	}
+	mechanism.GetParameters()[ServerRole] = m.role
+
+	log.FromContext(ctx).
+		WithField("memifServer", "request").
+		WithField("socket", socket).
+		WithField("role", m.role).
+		Debugf("set memif socket")
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil && !loaded {
+		m.cleanup(ctx)
+	}
+	return conn, err
+}
+
+func (m *memifServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	_, err := next.Server(ctx).Close(ctx, conn)
+	m.cleanup(ctx)
+	return &empty.Empty{}, err
+}
+
+func (m *memifServer) cleanup(ctx context.Context) {
+	socket, ok := loadAndDeleteSocket(ctx, metadata.IsClient(m))
+	if !ok || m.abstract {
+		return
+	}
+	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+		log.FromContext(ctx).WithField("memifServer", "cleanup").Warnf("failed to remove memif socket %s: %s", socket, err.Error())
+	}
+}
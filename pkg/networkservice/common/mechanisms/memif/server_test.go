@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memif_test
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	memifmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/memif"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+)
+
+func newRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.LOCAL,
+				Type: memifmech.MECHANISM,
+			},
+		},
+	}
+}
+
+func TestMemifServer_SocketFile(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	socketDir := filepath.Join(t.TempDir(), "memif")
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		memif.NewServer(memif.WithSocketDir(socketDir)),
+	)
+
+	conn, err := server.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+
+	socketURL, err := url.Parse(memifmech.ToMechanism(conn.GetMechanism()).GetSocketFileURL())
+	require.NoError(t, err)
+	require.Equal(t, memifmech.FileScheme, socketURL.Scheme)
+	require.Equal(t, socketDir, filepath.Dir(socketURL.Path))
+	require.Equal(t, memif.RoleMaster, memif.Role(conn.GetMechanism(), false))
+	require.Equal(t, memif.RoleSlave, memif.Role(conn.GetMechanism(), true))
+
+	// Refresh keeps the socket
+	refreshRequest := newRequest()
+	refreshRequest.GetConnection().GetMechanism().Parameters = map[string]string{
+		memifmech.SocketFileURL: "inode://1/2",
+	}
+	conn, err = server.Request(context.Background(), refreshRequest)
+	require.NoError(t, err)
+	require.Equal(t, socketURL.String(), memifmech.ToMechanism(conn.GetMechanism()).GetSocketFileURL())
+
+	// Data plane creates the socket, Close removes it
+	file, err := os.Create(socketURL.Path)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	_, err = os.Stat(socketURL.Path)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestMemifServer_AbstractSocket(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		memif.NewServer(memif.WithAbstractSockets(), memif.WithSlaveRole()),
+	)
+
+	conn, err := server.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+
+	mechanism := memifmech.ToMechanism(conn.GetMechanism())
+	require.NotEmpty(t, mechanism.GetSocketFilename())
+	require.Equal(t, "file:///proc/thread-self/ns/net", mechanism.GetNetNSURL())
+	require.Equal(t, memif.RoleSlave, memif.Role(conn.GetMechanism(), false))
+	require.Equal(t, memif.RoleMaster, memif.Role(conn.GetMechanism(), true))
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+}
+
+func TestMemifServer_NonMemif(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	request := newRequest()
+	request.GetConnection().Mechanism = &networkservice.Mechanism{
+		Cls:  cls.LOCAL,
+		Type: kernel.MECHANISM,
+	}
+
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		memif.NewServer(memif.WithSocketDir(t.TempDir())),
+	)
+	conn, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Empty(t, conn.GetMechanism().GetParameters())
+}
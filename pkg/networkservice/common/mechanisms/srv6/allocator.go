@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6
+
+import (
+	"net"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/tools/ippool"
+)
+
+type sids struct {
+	localSID net.IP
+	bsid     net.IP
+}
+
+// sidAllocator allocates per-connection SIDs from the locator prefix
+type sidAllocator struct {
+	pool    *ippool.IPPool
+	initErr error
+
+	// This map stores all allocated SIDs by Connection.Id
+	genericsync.Map[string, *sids]
+}
+
+func newSIDAllocator(locator *net.IPNet) *sidAllocator {
+	if locator == nil {
+		return &sidAllocator{
+			initErr: errors.New("locator must not be nil"),
+		}
+	}
+	if locator.IP.To4() != nil {
+		return &sidAllocator{
+			initErr: errors.Errorf("locator must be an IPv6 prefix: %s", locator.String()),
+		}
+	}
+	return &sidAllocator{
+		pool: ippool.NewWithNet(locator),
+	}
+}
+
+// allocate returns SIDs for the connection: already allocated ones, recovered localSID/bsid if they are still free
+// in the locator or new ones
+func (a *sidAllocator) allocate(id, localSID, bsid string) (s *sids, loaded bool, err error) {
+	if a.initErr != nil {
+		return nil, false, a.initErr
+	}
+	if s, loaded = a.Load(id); loaded {
+		return s, true, nil
+	}
+
+	s = new(sids)
+	if s.localSID, err = a.pull(localSID); err != nil {
+		return nil, false, err
+	}
+	if s.bsid, err = a.pull(bsid); err != nil {
+		a.pool.Add(s.localSID)
+		return nil, false, err
+	}
+	a.Store(id, s)
+	return s, false, nil
+}
+
+// pull recovers sid if it is still free in the locator, or returns a new one
+func (a *sidAllocator) pull(sid string) (net.IP, error) {
+	if ip := net.ParseIP(sid); ip != nil {
+		if ipNet, err := a.pool.PullIP(ip); err == nil {
+			return ipNet.IP, nil
+		}
+	}
+	ip, err := a.pool.Pull()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to allocate SID")
+	}
+	return ip, nil
+}
+
+func (a *sidAllocator) free(id string) {
+	if s, ok := a.LoadAndDelete(id); ok {
+		a.pool.Add(s.localSID)
+		a.pool.Add(s.bsid)
+	}
+}
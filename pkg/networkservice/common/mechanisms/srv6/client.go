@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/srv6"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+type srv6Client struct {
+	hostIP net.IP
+	*sidAllocator
+}
+
+// NewClient - set the SrcHostIP and allocate SrcLocalSID/SrcBSID from the locator for the srv6 mechanism
+//   - hostIP - local host IP
+//   - locator - IPv6 prefix SIDs are allocated from
+func NewClient(hostIP net.IP, locator *net.IPNet) networkservice.NetworkServiceClient {
+	return &srv6Client{
+		hostIP:       hostIP,
+		sidAllocator: newSIDAllocator(locator),
+	}
+}
+
+func (c *srv6Client) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	var mechanisms []*networkservice.Mechanism
+	for _, m := range append([]*networkservice.Mechanism{request.GetConnection().GetMechanism()}, request.GetMechanismPreferences()...) {
+		if srv6.ToMechanism(m) != nil {
+			mechanisms = append(mechanisms, m)
+		}
+	}
+	if len(mechanisms) == 0 {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+
+	// SIDs of an existing connection are recovered if possible
+	current := srv6.ToMechanism(request.GetConnection().GetMechanism())
+	var localSID, bsid string
+	if current != nil {
+		localSID, bsid = current.SrcLocalSID(), current.SrcBSID()
+	}
+
+	id := request.GetConnection().GetId()
+	s, loaded, err := c.allocate(id, localSID, bsid)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range mechanisms {
+		m.GetParameters()[srv6.SrcHostIP] = c.hostIP.String()
+		m.GetParameters()[srv6.SrcLocalSID] = s.localSID.String()
+		m.GetParameters()[srv6.SrcBSID] = s.bsid.String()
+	}
+
+	log.FromContext(ctx).
+		WithField("srv6Client", "request").
+		WithField("srcLocalSID", s.localSID).
+		WithField("srcBSID", s.bsid).
+		Debugf("set mechanism src")
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		if !loaded {
+			c.free(id)
+		}
+		return nil, err
+	}
+
+	if conn.GetMechanism() != nil && srv6.ToMechanism(conn.GetMechanism()) == nil {
+		// Another mechanism has been selected
+		c.free(id)
+	}
+	return conn, nil
+}
+
+func (c *srv6Client) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.free(conn.GetId())
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	srv6mech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/srv6"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/srv6"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+)
+
+func newRequest(id string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: id,
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{
+				Cls:  cls.REMOTE,
+				Type: srv6mech.MECHANISM,
+			},
+		},
+	}
+}
+
+func TestSRv6_SIDExchange(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	_, clientLocator, err := net.ParseCIDR("fc00:1::/64")
+	require.NoError(t, err)
+	_, serverLocator, err := net.ParseCIDR("fc00:2::/64")
+	require.NoError(t, err)
+
+	client := next.NewNetworkServiceClient(
+		srv6.NewClient(net.ParseIP("2001:db8::1"), clientLocator),
+		adapters.NewServerToClient(
+			mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
+				srv6mech.MECHANISM: srv6.NewServer(net.ParseIP("2001:db8::2"), serverLocator),
+			}),
+		),
+	)
+
+	conn, err := client.Request(context.Background(), newRequest("id"))
+	require.NoError(t, err)
+
+	mech := srv6mech.ToMechanism(conn.GetMechanism())
+	require.NotNil(t, mech)
+	require.Equal(t, "2001:db8::1", mech.SrcHostIP())
+	require.Equal(t, "2001:db8::2", mech.DstHostIP())
+	for _, sid := range []string{mech.SrcLocalSID(), mech.SrcBSID()} {
+		require.True(t, clientLocator.Contains(net.ParseIP(sid)), sid)
+	}
+	for _, sid := range []string{mech.DstLocalSID(), mech.DstBSID()} {
+		require.True(t, serverLocator.Contains(net.ParseIP(sid)), sid)
+	}
+	require.NotEqual(t, mech.SrcLocalSID(), mech.SrcBSID())
+	require.NotEqual(t, mech.DstLocalSID(), mech.DstBSID())
+
+	// Refresh keeps the SIDs
+	refreshRequest := newRequest("id")
+	refreshRequest.Connection = conn.Clone()
+	refreshed, err := client.Request(context.Background(), refreshRequest)
+	require.NoError(t, err)
+	require.Equal(t, conn.GetMechanism().GetParameters(), refreshed.GetMechanism().GetParameters())
+
+	_, err = client.Close(context.Background(), refreshed)
+	require.NoError(t, err)
+}
+
+func TestSRv6Client_Recover(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	_, locator, err := net.ParseCIDR("fc00:1::/126")
+	require.NoError(t, err)
+
+	request := newRequest("id")
+	request.GetConnection().Mechanism = &networkservice.Mechanism{
+		Cls:  cls.REMOTE,
+		Type: srv6mech.MECHANISM,
+		Parameters: map[string]string{
+			srv6mech.SrcLocalSID: "fc00:1::1",
+			srv6mech.SrcBSID:     "fc00:1::2",
+		},
+	}
+
+	// A restarted client recovers the SIDs of the existing connection
+	client := next.NewNetworkServiceClient(srv6.NewClient(net.ParseIP("2001:db8::1"), locator))
+	conn, err := client.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, "fc00:1::1", srv6mech.ToMechanism(conn.GetMechanism()).SrcLocalSID())
+	require.Equal(t, "fc00:1::2", srv6mech.ToMechanism(conn.GetMechanism()).SrcBSID())
+
+	// Recovered SIDs are not allocated to other connections
+	request2 := newRequest("id-2")
+	_, err = client.Request(context.Background(), request2)
+	require.NoError(t, err)
+	mech2 := srv6mech.ToMechanism(request2.GetMechanismPreferences()[0])
+	require.ElementsMatch(t, []string{"fc00:1::", "fc00:1::3"}, []string{mech2.SrcLocalSID(), mech2.SrcBSID()})
+
+	// The locator is exhausted
+	_, err = client.Request(context.Background(), newRequest("id-3"))
+	require.Error(t, err)
+
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	_, err = client.Request(context.Background(), newRequest("id-3"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/srv6"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+type srv6Server struct {
+	hostIP net.IP
+	*sidAllocator
+}
+
+// NewServer - set the DstHostIP and allocate DstLocalSID/DstBSID from the locator for the srv6 mechanism
+//   - hostIP - local host IP
+//   - locator - IPv6 prefix SIDs are allocated from
+func NewServer(hostIP net.IP, locator *net.IPNet) networkservice.NetworkServiceServer {
+	return &srv6Server{
+		hostIP:       hostIP,
+		sidAllocator: newSIDAllocator(locator),
+	}
+}
+
+func (s *srv6Server) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	logger := log.FromContext(ctx).WithField("srv6Server", "request")
+
+	id := request.GetConnection().GetId()
+	mechanism := srv6.ToMechanism(request.GetConnection().GetMechanism())
+	if mechanism == nil {
+		// Another mechanism may have been selected
+		s.free(id)
+		logger.Debugf("mechanism is not srv6")
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	// SIDs of an existing connection are recovered if possible
+	sids, loaded, err := s.allocate(id, mechanism.DstLocalSID(), mechanism.DstBSID())
+	if err != nil {
+		return nil, err
+	}
+	params := request.GetConnection().GetMechanism().GetParameters()
+	params[srv6.DstHostIP] = s.hostIP.String()
+	params[srv6.DstLocalSID] = sids.localSID.String()
+	params[srv6.DstBSID] = sids.bsid.String()
+
+	logger.WithField("dstLocalSID", sids.localSID).WithField("dstBSID", sids.bsid).Debugf("set mechanism dst")
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil && !loaded {
+		s.free(id)
+	}
+	return conn, err
+}
+
+func (s *srv6Server) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.free(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
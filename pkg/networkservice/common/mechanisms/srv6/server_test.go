@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	srv6mech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/srv6"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/srv6"
+)
+
+func newServerRequest(id string, params map[string]string) *networkservice.NetworkServiceRequest {
+	request := newRequest(id)
+	request.GetConnection().Mechanism = &networkservice.Mechanism{
+		Cls:        cls.REMOTE,
+		Type:       srv6mech.MECHANISM,
+		Parameters: params,
+	}
+	return request
+}
+
+func TestSRv6Server_Recover(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	_, locator, err := net.ParseCIDR("fc00:2::/64")
+	require.NoError(t, err)
+
+	server := srv6.NewServer(net.ParseIP("2001:db8::2"), locator)
+
+	conn1, err := server.Request(context.Background(), newServerRequest("id-1", nil))
+	require.NoError(t, err)
+	mech1 := srv6mech.ToMechanism(conn1.GetMechanism())
+
+	// A restarted server recovers the SIDs of the existing connection
+	restarted := srv6.NewServer(net.ParseIP("2001:db8::2"), locator)
+	conn1, err = restarted.Request(context.Background(), newServerRequest("id-1", map[string]string{
+		srv6mech.DstLocalSID: mech1.DstLocalSID(),
+		srv6mech.DstBSID:     mech1.DstBSID(),
+	}))
+	require.NoError(t, err)
+	recovered := srv6mech.ToMechanism(conn1.GetMechanism())
+	require.Equal(t, mech1.DstLocalSID(), recovered.DstLocalSID())
+	require.Equal(t, mech1.DstBSID(), recovered.DstBSID())
+
+	// SIDs already allocated to another connection are not recovered twice
+	conn2, err := restarted.Request(context.Background(), newServerRequest("id-2", map[string]string{
+		srv6mech.DstLocalSID: mech1.DstLocalSID(),
+		srv6mech.DstBSID:     mech1.DstBSID(),
+	}))
+	require.NoError(t, err)
+	mech2 := srv6mech.ToMechanism(conn2.GetMechanism())
+	require.NotContains(t, []string{mech1.DstLocalSID(), mech1.DstBSID()}, mech2.DstLocalSID())
+	require.NotContains(t, []string{mech1.DstLocalSID(), mech1.DstBSID()}, mech2.DstBSID())
+}
+
+func TestSRv6Server_InvalidLocator(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	_, ipv4Locator, err := net.ParseCIDR("192.0.2.0/24")
+	require.NoError(t, err)
+
+	for _, locator := range []*net.IPNet{nil, ipv4Locator} {
+		_, err = srv6.NewServer(net.ParseIP("2001:db8::2"), locator).Request(context.Background(), newServerRequest("id", nil))
+		require.Error(t, err)
+	}
+}
+
+func TestSRv6Server_NonSRv6(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	_, locator, err := net.ParseCIDR("fc00:2::/64")
+	require.NoError(t, err)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.LOCAL,
+				Type: kernel.MECHANISM,
+			},
+		},
+	}
+	conn, err := srv6.NewServer(net.ParseIP("2001:db8::2"), locator).Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Nil(t, srv6mech.ToMechanism(conn.GetMechanism()))
+}
@@ -18,4 +18,7 @@ package vni
 
 const (
 	vxlanPort = 4789
+
+	minVNI = 1
+	maxVNI = 0xFFFFFF
 )
@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vni
+
+import (
+	"context"
+	"hash/fnv"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+)
+
+// generateVNI reserves a VNI for the connection. The VNI is taken from the configured range with the mechanism
+// parity (odd or even) starting from a position derived from the connection ID, so the same connection gets the
+// same VNI back after a restart. Reserved VNIs, VNIs in use for the same source IP and VNIs conflicting with the
+// host are skipped.
+func (v *vniServer) generateVNI(ctx context.Context, id string, mechanism *vxlan.Mechanism, srcIPString string) (uint32, error) {
+	if mechanism.SrcIP() == nil || mechanism.DstIP() == nil {
+		return 0, errors.Errorf("failed to generate a VNI: both srcIP(%s) and dstIP(%s) must be non-nil", mechanism.SrcIP(), mechanism.DstIP())
+	}
+
+	first, last := v.vniRange(mechanism)
+	if first > last {
+		return 0, errors.Errorf("failed to generate a VNI: no VNIs available in range [%d, %d]", v.minVNI, v.maxVNI)
+	}
+	count := uint64(last-first)/2 + 1
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(id))
+	start := h.Sum64() % count
+
+	for i := uint64(0); i < count; i++ {
+		vni := first + uint32(2*((start+i)%count))
+		if v.reserveVNI(ctx, vniKey{srcIPString: srcIPString, vni: vni}) {
+			return vni, nil
+		}
+	}
+	return 0, errors.Errorf("failed to generate a VNI: all VNIs in range [%d, %d] are in use", v.minVNI, v.maxVNI)
+}
+
+// reserveCarriedVNI reserves the VNI carried by the request if it could have been generated by the server: it is in
+// the configured range, has the mechanism parity, is not reserved, is not in use for the same source IP and doesn't
+// conflict with the host.
+func (v *vniServer) reserveCarriedVNI(ctx context.Context, mechanism *vxlan.Mechanism, k vniKey) bool {
+	first, last := v.vniRange(mechanism)
+	if k.vni < first || k.vni > last || (k.vni%2 == 0) != mechanism.EvenVNI() {
+		return false
+	}
+	return v.reserveVNI(ctx, k)
+}
+
+// vniRange returns the first and the last VNIs of the mechanism parity (odd or even) in the configured range. Only
+// VNIs of the mechanism parity are used, so both sides never generate the same VNI.
+func (v *vniServer) vniRange(mechanism *vxlan.Mechanism) (first, last uint32) {
+	first = v.minVNI
+	if first < minVNI {
+		first = minVNI
+	}
+	last = v.maxVNI
+	if last > maxVNI {
+		last = maxVNI
+	}
+	if (first%2 == 0) != mechanism.EvenVNI() {
+		first++
+	}
+	return first, last
+}
+
+// reserveVNI stores k if its VNI is not reserved, not in use for the same source IP and doesn't conflict with the host
+func (v *vniServer) reserveVNI(ctx context.Context, k vniKey) bool {
+	if _, ok := v.reservedVNIs[k.vni]; ok {
+		return false
+	}
+	if _, ok := v.Map.Load(k); ok {
+		return false
+	}
+	if v.hostConflict != nil && v.hostConflict(ctx, k.vni) {
+		return false
+	}
+	_, ok := v.Map.LoadOrStore(k, &k)
+	return !ok
+}
@@ -18,9 +18,16 @@
 
 package vni
 
+import (
+	"context"
+)
+
 // Option is an option pattern for vni server/client
 type Option func(o *vniOpions)
 
+// HostConflictFunc returns true if the VNI is already in use on the host (e.g. by an EVPN fabric)
+type HostConflictFunc func(ctx context.Context, vni uint32) bool
+
 // WithTunnelPort sets VxLAN port
 func WithTunnelPort(tunnelPort uint16) Option {
 	return func(o *vniOpions) {
@@ -30,6 +37,37 @@ func WithTunnelPort(tunnelPort uint16) Option {
 	}
 }
 
+// WithVNIRange restricts VNIs generated by the server to [minVNI, maxVNI]
+func WithVNIRange(minVNI, maxVNI uint32) Option {
+	return func(o *vniOpions) {
+		o.minVNI = minVNI
+		o.maxVNI = maxVNI
+	}
+}
+
+// WithReservedVNIs sets VNIs the server never generates
+func WithReservedVNIs(vnis ...uint32) Option {
+	return func(o *vniOpions) {
+		if o.reservedVNIs == nil {
+			o.reservedVNIs = make(map[uint32]struct{})
+		}
+		for _, vni := range vnis {
+			o.reservedVNIs[vni] = struct{}{}
+		}
+	}
+}
+
+// WithHostConflictFunc sets the function checking if a generated VNI is already in use on the host
+func WithHostConflictFunc(hostConflict HostConflictFunc) Option {
+	return func(o *vniOpions) {
+		o.hostConflict = hostConflict
+	}
+}
+
 type vniOpions struct {
-	tunnelPort uint16
+	tunnelPort   uint16
+	minVNI       uint32
+	maxVNI       uint32
+	reservedVNIs map[uint32]struct{}
+	hostConflict HostConflictFunc
 }
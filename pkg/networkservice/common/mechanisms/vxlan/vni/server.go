@@ -29,7 +29,6 @@ import (
 	"github.com/ljkiraly/sdk/pkg/tools/log"
 
 	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
@@ -46,6 +45,7 @@ type vniKey struct {
 type vniServer struct {
 	tunnelIP   net.IP
 	tunnelPort uint16
+	*vniOpions
 
 	// This map stores all generated VNIs
 	genericsync.Map[vniKey, *vniKey]
@@ -55,6 +55,8 @@ type vniServer struct {
 func NewServer(tunnelIP net.IP, options ...Option) networkservice.NetworkServiceServer {
 	opts := &vniOpions{
 		tunnelPort: vxlanPort,
+		minVNI:     minVNI,
+		maxVNI:     maxVNI,
 	}
 	for _, opt := range options {
 		opt(opts)
@@ -63,6 +65,7 @@ func NewServer(tunnelIP net.IP, options ...Option) networkservice.NetworkService
 	return &vniServer{
 		tunnelIP:   tunnelIP,
 		tunnelPort: opts.tunnelPort,
+		vniOpions:  opts,
 	}
 }
 
@@ -84,43 +87,39 @@ func (v *vniServer) Request(ctx context.Context, request *networkservice.Network
 		vni:         mechanism.VNI(),
 	}
 
-	// If we already have a VNI, make sure we remember it, and go on
-	if k.vni != 0 && mechanism.SrcIP() != nil {
-		_, _ = v.Map.LoadOrStore(k, &k)
-		_, loaded := loadOrStore(ctx, metadata.IsClient(v), k.vni)
+	vni, loaded := load(ctx, metadata.IsClient(v))
 
-		logger.WithField("vni", k.vni).Debugf("loadOrStore vni in metadata")
+	// If we already have a VNI, make sure it is the one we've stored or it passes the same checks as the generated
+	// ones, and go on. Otherwise a new VNI is generated.
+	if k.vni != 0 && mechanism.SrcIP() != nil && !(loaded && vni == k.vni) {
+		if !loaded && v.reserveCarriedVNI(ctx, mechanism, k) {
+			store(ctx, metadata.IsClient(v), k.vni)
 
-		conn, err := next.Server(ctx).Request(ctx, request)
-		if err != nil && !loaded {
-			delete(ctx, metadata.IsClient(v))
-			v.Map.Delete(k)
+			logger.WithField("vni", k.vni).Debugf("vni reserved and stored in metadata")
 
-			logger.WithField("vni", k.vni).Errorf("error returned from request, deleting vni. err=%v", err.Error())
+			conn, err := next.Server(ctx).Request(ctx, request)
+			if err != nil {
+				delete(ctx, metadata.IsClient(v))
+				v.Map.Delete(k)
+
+				logger.WithField("vni", k.vni).Errorf("error returned from request, deleting vni. err=%v", err.Error())
+			}
+			return conn, err
 		}
-		return conn, err
+		logger.WithField("vni", k.vni).Warnf("vni is rejected, replacing it")
 	}
 
-	vni, loaded := load(ctx, metadata.IsClient(v))
 	if loaded {
 		mechanism.SetVNI(vni)
 		logger.WithField("vni", vni).Debugf("vni loaded from metadata")
 	} else {
-		for {
-			// Generate a random VNI (appropriately odd or even)
-			var err error
-			k.vni, err = mechanism.GenerateRandomVNI()
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to generate a random VNI")
-			}
-			// If its not one already in use, set it and we are good to go
-			if _, ok := v.Map.LoadOrStore(k, &k); !ok {
-				mechanism.SetVNI(k.vni)
-				store(ctx, metadata.IsClient(v), k.vni)
-				logger.WithField("vni", k.vni).Debugf("vni generated and stored in metadata")
-				break
-			}
+		var err error
+		if k.vni, err = v.generateVNI(ctx, request.GetConnection().GetId(), mechanism, k.srcIPString); err != nil {
+			return nil, err
 		}
+		mechanism.SetVNI(k.vni)
+		store(ctx, metadata.IsClient(v), k.vni)
+		logger.WithField("vni", k.vni).Debugf("vni generated and stored in metadata")
 	}
 
 	conn, err := next.Server(ctx).Request(ctx, request)
@@ -26,6 +26,7 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/vxlan/vni"
@@ -99,3 +100,104 @@ func TestVNIServerNonVxLAN(t *testing.T) {
 	assert.NotNil(t, conn)
 	assert.Nil(t, vxlan.ToMechanism(conn.GetMechanism()))
 }
+
+func newVNIRequest(id string) *networkservice.NetworkServiceRequest {
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: id,
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.REMOTE,
+				Type: vxlan.MECHANISM,
+			},
+		},
+	}
+	vxlan.ToMechanism(request.GetConnection().GetMechanism()).SetSrcIP(net.ParseIP("192.0.2.1"))
+	return request
+}
+
+func TestVNIServerDeterministic(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	newServer := func() networkservice.NetworkServiceServer {
+		return next.NewNetworkServiceServer(
+			metadata.NewServer(),
+			vni.NewServer(net.ParseIP("192.0.2.2")),
+		)
+	}
+
+	conn, err := newServer().Request(context.Background(), newVNIRequest("id"))
+	require.NoError(t, err)
+
+	// Restarted server generates the same VNI for the same connection
+	restartedConn, err := newServer().Request(context.Background(), newVNIRequest("id"))
+	require.NoError(t, err)
+	require.Equal(t, vxlan.ToMechanism(conn.GetMechanism()).VNI(), vxlan.ToMechanism(restartedConn.GetMechanism()).VNI())
+}
+
+func TestVNIServerRangeAndConflicts(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		vni.NewServer(net.ParseIP("192.0.2.2"),
+			vni.WithVNIRange(100, 110),
+			vni.WithReservedVNIs(100, 102),
+			vni.WithHostConflictFunc(func(_ context.Context, vni uint32) bool {
+				return vni == 104
+			}),
+		),
+	)
+
+	// 192.0.2.1 < 192.0.2.2, so the server generates even VNIs
+	var vnis []uint32
+	for _, id := range []string{"id-1", "id-2", "id-3"} {
+		conn, err := server.Request(context.Background(), newVNIRequest(id))
+		require.NoError(t, err)
+		vnis = append(vnis, vxlan.ToMechanism(conn.GetMechanism()).VNI())
+	}
+	require.ElementsMatch(t, []uint32{106, 108, 110}, vnis)
+
+	_, err := server.Request(context.Background(), newVNIRequest("id-4"))
+	require.Error(t, err)
+}
+
+func TestVNIServerCarriedVNI(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		vni.NewServer(net.ParseIP("192.0.2.2"),
+			vni.WithVNIRange(100, 110),
+			vni.WithReservedVNIs(102),
+			vni.WithHostConflictFunc(func(_ context.Context, vni uint32) bool {
+				return vni == 104
+			}),
+		),
+	)
+
+	request := func(id string, carried uint32) uint32 {
+		vniRequest := newVNIRequest(id)
+		vxlan.ToMechanism(vniRequest.GetConnection().GetMechanism()).SetVNI(carried)
+		conn, err := server.Request(context.Background(), vniRequest)
+		require.NoError(t, err)
+		return vxlan.ToMechanism(conn.GetMechanism()).VNI()
+	}
+
+	// Valid carried VNI is kept, also on refresh
+	require.Equal(t, uint32(106), request("id-1", 106))
+	require.Equal(t, uint32(106), request("id-1", 106))
+
+	// VNIs in use, conflicting with the host, reserved, out of range or of the wrong parity are replaced
+	var vnis []uint32
+	for id, carried := range map[string]uint32{"id-2": 106, "id-3": 104, "id-4": 200} {
+		vnis = append(vnis, request(id, carried))
+	}
+	require.ElementsMatch(t, []uint32{100, 108, 110}, vnis)
+
+	for _, carried := range []uint32{102, 103} {
+		vniRequest := newVNIRequest("id-5")
+		vxlan.ToMechanism(vniRequest.GetConnection().GetMechanism()).SetVNI(carried)
+		_, err := server.Request(context.Background(), vniRequest)
+		require.Error(t, err)
+	}
+}
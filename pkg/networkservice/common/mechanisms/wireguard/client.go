@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/postpone"
+)
+
+type wireguardClient struct {
+	tunnelIP net.IP
+	ports    *portPool
+}
+
+// NewClient - set the SrcIP, SrcPort and SrcPublicKey for the wireguard mechanism, a new key pair is generated
+// on each Request
+func NewClient(tunnelIP net.IP, options ...Option) networkservice.NetworkServiceClient {
+	opts := newOptions(options...)
+
+	return &wireguardClient{
+		tunnelIP: tunnelIP,
+		ports:    newPortPool(opts.minPort, opts.maxPort),
+	}
+}
+
+func (c *wireguardClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	logger := log.FromContext(ctx).WithField("wireguardClient", "request")
+
+	mechanisms := request.GetMechanismPreferences()
+	if m := request.GetConnection().GetMechanism(); m != nil {
+		mechanisms = append([]*networkservice.Mechanism{m}, mechanisms...)
+	}
+
+	prev, loaded := Load(ctx, metadata.IsClient(c))
+
+	var cfg *Config
+	for _, m := range mechanisms {
+		mech := wireguard.ToMechanism(m)
+		if mech == nil {
+			continue
+		}
+		if cfg == nil {
+			var err error
+			if cfg, err = c.ports.nextConfig(prev); err != nil {
+				return nil, err
+			}
+		}
+		mech.SetSrcIP(c.tunnelIP).SetSrcPort(cfg.ListenPort).SetSrcPublicKey(cfg.PublicKey)
+	}
+	if cfg == nil {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+
+	logger.WithField("srcPort", cfg.ListenPort).WithField("srcPublicKey", cfg.PublicKey).Debugf("set mechanism src")
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		if !loaded {
+			c.ports.release(cfg.ListenPort)
+		}
+		return nil, err
+	}
+
+	mech := wireguard.ToMechanism(conn.GetMechanism())
+	if mech == nil {
+		// Another mechanism has been selected
+		c.ports.release(cfg.ListenPort)
+		del(ctx, metadata.IsClient(c))
+		return conn, nil
+	}
+
+	if mech.DstPublicKey() == "" {
+		err = errors.New("wireguard mechanism has no destination public key")
+		if !loaded {
+			c.ports.release(cfg.ListenPort)
+
+			closeCtx, cancelClose := postponeCtxFunc()
+			defer cancelClose()
+
+			if _, closeErr := next.Client(ctx).Close(closeCtx, conn, opts...); closeErr != nil {
+				err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+			}
+		}
+		return nil, err
+	}
+
+	cfg.PeerIP = mech.DstIP()
+	cfg.PeerPort = mech.DstPort()
+	cfg.PeerPublicKey = mech.DstPublicKey()
+	cfg.AllowedIPs = allowedIPs(conn, metadata.IsClient(c))
+	store(ctx, metadata.IsClient(c), cfg)
+
+	return conn, nil
+}
+
+func (c *wireguardClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if cfg, ok := Load(ctx, metadata.IsClient(c)); ok {
+		c.ports.release(cfg.ListenPort)
+		del(ctx, metadata.IsClient(c))
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
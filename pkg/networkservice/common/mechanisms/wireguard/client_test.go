@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	wireguardmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/wireguard"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+)
+
+func newRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Context: &networkservice.ConnectionContext{
+				IpContext: &networkservice.IPContext{
+					SrcIpAddrs: []string{"172.16.0.1/32"},
+					DstIpAddrs: []string{"172.16.0.0/32"},
+					SrcRoutes:  []*networkservice.Route{{Prefix: "10.0.0.0/24"}, {Prefix: "172.16.0.0/32"}},
+					DstRoutes:  []*networkservice.Route{{Prefix: "172.16.0.1/32"}},
+				},
+			},
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{
+				Cls:  cls.REMOTE,
+				Type: wireguardmech.MECHANISM,
+			},
+		},
+	}
+}
+
+func requirePublicKey(t *testing.T, cfg *wireguard.Config) {
+	priv, err := base64.StdEncoding.DecodeString(cfg.PrivateKey)
+	require.NoError(t, err)
+	pub, err := curve25519.X25519(priv, curve25519.Basepoint)
+	require.NoError(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString(pub), cfg.PublicKey)
+}
+
+func TestWireguard_KeyExchange(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	var serverCtx, clientCtx context.Context
+	client := next.NewNetworkServiceClient(
+		metadata.NewClient(),
+		checkcontext.NewClient(t, func(_ *testing.T, ctx context.Context) {
+			clientCtx = ctx
+		}),
+		wireguard.NewClient(net.ParseIP("192.0.2.1"), wireguard.WithPortRange(51000, 51001)),
+		adapters.NewServerToClient(next.NewNetworkServiceServer(
+			metadata.NewServer(),
+			mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
+				wireguardmech.MECHANISM: wireguard.NewServer(net.ParseIP("192.0.2.2"), wireguard.WithPortRange(52000, 52001)),
+			}),
+			checkcontext.NewServer(t, func(_ *testing.T, ctx context.Context) {
+				serverCtx = ctx
+			}),
+		)),
+	)
+
+	conn, err := client.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+
+	mech := wireguardmech.ToMechanism(conn.GetMechanism())
+	require.NotNil(t, mech)
+	require.Equal(t, "192.0.2.1", mech.SrcIP().String())
+	require.Equal(t, "192.0.2.2", mech.DstIP().String())
+	require.Equal(t, uint16(51000), mech.SrcPort())
+	require.Equal(t, uint16(52000), mech.DstPort())
+
+	clientCfg, ok := wireguard.Load(clientCtx, true)
+	require.True(t, ok)
+	serverCfg, ok := wireguard.Load(serverCtx, false)
+	require.True(t, ok)
+
+	requirePublicKey(t, clientCfg)
+	requirePublicKey(t, serverCfg)
+	require.Equal(t, clientCfg.PublicKey, mech.SrcPublicKey())
+	require.Equal(t, serverCfg.PublicKey, mech.DstPublicKey())
+	require.Equal(t, serverCfg.PublicKey, clientCfg.PeerPublicKey)
+	require.Equal(t, clientCfg.PublicKey, serverCfg.PeerPublicKey)
+	require.Equal(t, uint16(52000), clientCfg.PeerPort)
+	require.Equal(t, uint16(51000), serverCfg.PeerPort)
+	require.Equal(t, "192.0.2.2", clientCfg.PeerIP.String())
+	require.Equal(t, "192.0.2.1", serverCfg.PeerIP.String())
+
+	var clientAllowedIPs, serverAllowedIPs []string
+	for _, ipNet := range clientCfg.AllowedIPs {
+		clientAllowedIPs = append(clientAllowedIPs, ipNet.String())
+	}
+	for _, ipNet := range serverCfg.AllowedIPs {
+		serverAllowedIPs = append(serverAllowedIPs, ipNet.String())
+	}
+	require.Equal(t, []string{"172.16.0.0/32", "10.0.0.0/24"}, clientAllowedIPs)
+	require.Equal(t, []string{"172.16.0.1/32"}, serverAllowedIPs)
+
+	// Refresh rotates the keys on both sides and keeps the ports
+	refreshRequest := newRequest()
+	refreshRequest.Connection = conn.Clone()
+	conn, err = client.Request(context.Background(), refreshRequest)
+	require.NoError(t, err)
+
+	rotatedClientCfg, ok := wireguard.Load(clientCtx, true)
+	require.True(t, ok)
+	rotatedServerCfg, ok := wireguard.Load(serverCtx, false)
+	require.True(t, ok)
+
+	require.NotEqual(t, clientCfg.PrivateKey, rotatedClientCfg.PrivateKey)
+	require.NotEqual(t, serverCfg.PrivateKey, rotatedServerCfg.PrivateKey)
+	require.Equal(t, rotatedServerCfg.PublicKey, rotatedClientCfg.PeerPublicKey)
+	require.Equal(t, rotatedClientCfg.PublicKey, rotatedServerCfg.PeerPublicKey)
+	require.Equal(t, clientCfg.ListenPort, rotatedClientCfg.ListenPort)
+	require.Equal(t, serverCfg.ListenPort, rotatedServerCfg.ListenPort)
+
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// generateKeyPair returns a new base64 encoded WireGuard (Curve25519) key pair
+func generateKeyPair() (privateKey, publicKey string, err error) {
+	var priv [curve25519.ScalarSize]byte
+	if _, err = rand.Read(priv[:]); err != nil {
+		return "", "", errors.Wrap(err, "failed to generate WireGuard private key")
+	}
+	// Clamp the private key the same way wg(8) does
+	priv[0] &= 248
+	priv[31] = (priv[31] & 127) | 64
+
+	pub, err := curve25519.X25519(priv[:], curve25519.Basepoint)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to derive WireGuard public key")
+	}
+	return base64.StdEncoding.EncodeToString(priv[:]), base64.StdEncoding.EncodeToString(pub), nil
+}
+
+type portPool struct {
+	mu      sync.Mutex
+	minPort uint16
+	maxPort uint16
+	next    uint16
+	used    map[uint16]struct{}
+}
+
+func newPortPool(minPort, maxPort uint16) *portPool {
+	return &portPool{
+		minPort: minPort,
+		maxPort: maxPort,
+		next:    minPort,
+		used:    make(map[uint16]struct{}),
+	}
+}
+
+func (p *portPool) allocate() (uint16, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	size := int(p.maxPort) - int(p.minPort) + 1
+	for i := 0; i < size; i++ {
+		port := p.next
+		if p.next == p.maxPort {
+			p.next = p.minPort
+		} else {
+			p.next++
+		}
+		if _, ok := p.used[port]; !ok {
+			p.used[port] = struct{}{}
+			return port, nil
+		}
+	}
+	return 0, errors.Errorf("no free WireGuard listen port in range [%d, %d]", p.minPort, p.maxPort)
+}
+
+func (p *portPool) release(port uint16) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.used, port)
+}
+
+// nextConfig keeps the listen port of prev (allocating a new one if there is no prev) and generates a new key pair
+func (p *portPool) nextConfig(prev *Config) (*Config, error) {
+	cfg := new(Config)
+	if prev != nil {
+		cfg.ListenPort = prev.ListenPort
+	} else {
+		port, err := p.allocate()
+		if err != nil {
+			return nil, err
+		}
+		cfg.ListenPort = port
+	}
+
+	var err error
+	if cfg.PrivateKey, cfg.PublicKey, err = generateKeyPair(); err != nil {
+		if prev == nil {
+			p.release(cfg.ListenPort)
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// allowedIPs returns the prefixes the peer is allowed to send from: the peer addresses and the routes
+// pointing to the peer
+func allowedIPs(conn *networkservice.Connection, isClient bool) []*net.IPNet {
+	ipContext := conn.GetContext().GetIpContext()
+
+	var prefixes []string
+	if isClient {
+		prefixes = append(prefixes, ipContext.GetDstIpAddrs()...)
+		for _, route := range ipContext.GetSrcRoutes() {
+			prefixes = append(prefixes, route.GetPrefix())
+		}
+	} else {
+		prefixes = append(prefixes, ipContext.GetSrcIpAddrs()...)
+		for _, route := range ipContext.GetDstRoutes() {
+			prefixes = append(prefixes, route.GetPrefix())
+		}
+	}
+
+	var result []*net.IPNet
+	seen := make(map[string]struct{})
+	for _, prefix := range prefixes {
+		_, ipNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[ipNet.String()]; ok {
+			continue
+		}
+		seen[ipNet.String()] = struct{}{}
+		result = append(result, ipNet)
+	}
+	return result
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wireguard provides networkservice.NetworkService{Client,Server} chain elements handling the control plane
+// of the WireGuard remote mechanism: per-connection key pairs, public key exchange, listen port allocation and
+// AllowedIPs derived from the IPContext. Keys are rotated on every refresh. The resulting data plane configuration
+// is available to the forwarder through Load.
+package wireguard
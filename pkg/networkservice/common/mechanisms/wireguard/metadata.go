@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"context"
+	"net"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+)
+
+// Config - per-connection WireGuard configuration the forwarder needs to program the data plane
+type Config struct {
+	// PrivateKey - base64 encoded local private key, it never leaves the local side
+	PrivateKey string
+	// PublicKey - base64 encoded local public key, sent to the peer in the mechanism parameters
+	PublicKey string
+	// ListenPort - local listen port
+	ListenPort uint16
+	// PeerIP - peer tunnel IP
+	PeerIP net.IP
+	// PeerPublicKey - base64 encoded peer public key
+	PeerPublicKey string
+	// PeerPort - peer listen port
+	PeerPort uint16
+	// AllowedIPs - prefixes the peer is allowed to send from
+	AllowedIPs []*net.IPNet
+}
+
+type key struct{}
+
+// Load returns the WireGuard Config stored in per Connection.Id metadata
+func Load(ctx context.Context, isClient bool) (cfg *Config, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(key{})
+	if !ok {
+		return nil, false
+	}
+	cfg, ok = rawValue.(*Config)
+	return cfg, ok
+}
+
+func store(ctx context.Context, isClient bool, cfg *Config) {
+	metadata.Map(ctx, isClient).Store(key{}, cfg)
+}
+
+func del(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(key{})
+}
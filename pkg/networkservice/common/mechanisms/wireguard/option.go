@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+// Option is an option pattern for wireguard server/client
+type Option func(o *wireguardOptions)
+
+// WithPortRange sets the range [minPort, maxPort] WireGuard listen ports are allocated from
+func WithPortRange(minPort, maxPort uint16) Option {
+	return func(o *wireguardOptions) {
+		if minPort != 0 && minPort <= maxPort {
+			o.minPort = minPort
+			o.maxPort = maxPort
+		}
+	}
+}
+
+type wireguardOptions struct {
+	minPort uint16
+	maxPort uint16
+}
+
+func newOptions(options ...Option) *wireguardOptions {
+	opts := &wireguardOptions{
+		minPort: defaultMinPort,
+		maxPort: defaultMaxPort,
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+	return opts
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+type wireguardServer struct {
+	tunnelIP net.IP
+	ports    *portPool
+}
+
+// NewServer - set the DstIP, DstPort and DstPublicKey for the wireguard mechanism, a new key pair is generated
+// on each Request
+func NewServer(tunnelIP net.IP, options ...Option) networkservice.NetworkServiceServer {
+	opts := newOptions(options...)
+
+	return &wireguardServer{
+		tunnelIP: tunnelIP,
+		ports:    newPortPool(opts.minPort, opts.maxPort),
+	}
+}
+
+func (s *wireguardServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	logger := log.FromContext(ctx).WithField("wireguardServer", "request")
+
+	prev, loaded := Load(ctx, metadata.IsClient(s))
+
+	mech := wireguard.ToMechanism(request.GetConnection().GetMechanism())
+	if mech == nil {
+		if loaded {
+			// Another mechanism has been selected
+			s.ports.release(prev.ListenPort)
+			del(ctx, metadata.IsClient(s))
+		}
+		logger.Debugf("mechanism is not wireguard")
+		return next.Server(ctx).Request(ctx, request)
+	}
+	if mech.SrcPublicKey() == "" {
+		return nil, errors.New("wireguard mechanism has no source public key")
+	}
+
+	cfg, err := s.ports.nextConfig(prev)
+	if err != nil {
+		return nil, err
+	}
+	mech.SetDstIP(s.tunnelIP).SetDstPort(cfg.ListenPort).SetDstPublicKey(cfg.PublicKey)
+	cfg.PeerIP = mech.SrcIP()
+	cfg.PeerPort = mech.SrcPort()
+	cfg.PeerPublicKey = mech.SrcPublicKey()
+
+	logger.WithField("dstPort", cfg.ListenPort).WithField("dstPublicKey", cfg.PublicKey).Debugf("set mechanism dst")
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !loaded {
+			s.ports.release(cfg.ListenPort)
+		}
+		return nil, err
+	}
+
+	cfg.AllowedIPs = allowedIPs(conn, metadata.IsClient(s))
+	store(ctx, metadata.IsClient(s), cfg)
+
+	return conn, nil
+}
+
+func (s *wireguardServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if cfg, ok := Load(ctx, metadata.IsClient(s)); ok {
+		s.ports.release(cfg.ListenPort)
+		del(ctx, metadata.IsClient(s))
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
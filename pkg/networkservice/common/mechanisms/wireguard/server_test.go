@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	wireguardmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/wireguard"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+)
+
+func newServerRequest(id string) *networkservice.NetworkServiceRequest {
+	request := newRequest()
+	request.GetConnection().Id = id
+	request.GetConnection().Mechanism = &networkservice.Mechanism{
+		Cls:  cls.REMOTE,
+		Type: wireguardmech.MECHANISM,
+	}
+	wireguardmech.ToMechanism(request.GetConnection().GetMechanism()).
+		SetSrcIP(net.ParseIP("192.0.2.1")).
+		SetSrcPort(51820).
+		SetSrcPublicKey("src-public-key")
+	return request
+}
+
+func TestWireguardServer_PortAllocation(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		wireguard.NewServer(net.ParseIP("192.0.2.2"), wireguard.WithPortRange(52000, 52001)),
+	)
+
+	conn1, err := server.Request(context.Background(), newServerRequest("id-1"))
+	require.NoError(t, err)
+	require.Equal(t, uint16(52000), wireguardmech.ToMechanism(conn1.GetMechanism()).DstPort())
+
+	conn2, err := server.Request(context.Background(), newServerRequest("id-2"))
+	require.NoError(t, err)
+	require.Equal(t, uint16(52001), wireguardmech.ToMechanism(conn2.GetMechanism()).DstPort())
+
+	_, err = server.Request(context.Background(), newServerRequest("id-3"))
+	require.Error(t, err)
+
+	_, err = server.Close(context.Background(), conn1)
+	require.NoError(t, err)
+
+	conn3, err := server.Request(context.Background(), newServerRequest("id-3"))
+	require.NoError(t, err)
+	require.Equal(t, uint16(52000), wireguardmech.ToMechanism(conn3.GetMechanism()).DstPort())
+}
+
+func TestWireguardServer_NoSrcPublicKey(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		wireguard.NewServer(net.ParseIP("192.0.2.2")),
+	)
+
+	request := newServerRequest("id")
+	wireguardmech.ToMechanism(request.GetConnection().GetMechanism()).SetSrcPublicKey("")
+
+	_, err := server.Request(context.Background(), request)
+	require.Error(t, err)
+}
+
+func TestWireguardServer_NonWireguard(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.LOCAL,
+				Type: kernel.MECHANISM,
+			},
+		},
+	}
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		wireguard.NewServer(net.ParseIP("192.0.2.2")),
+	)
+	conn, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Nil(t, wireguardmech.ToMechanism(conn.GetMechanism()))
+}
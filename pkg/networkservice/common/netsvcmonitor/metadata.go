@@ -22,15 +22,20 @@ import (
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
 )
 
-type cancelFunctionKey struct{}
+type networkServiceNameKey struct{}
 
-func storeCancelFunction(ctx context.Context, cancel func()) {
-	metadata.Map(ctx, false).Store(cancelFunctionKey{}, cancel)
+func storeNetworkServiceName(ctx context.Context, name string) {
+	metadata.Map(ctx, false).Store(networkServiceNameKey{}, name)
 }
-func loadCancelFunction(ctx context.Context) (func(), bool) {
-	v, ok := metadata.Map(ctx, false).Load(cancelFunctionKey{})
+
+func loadNetworkServiceName(ctx context.Context) (string, bool) {
+	v, ok := metadata.Map(ctx, false).Load(networkServiceNameKey{})
 	if ok {
-		return v.(func()), true
+		return v.(string), true
 	}
-	return nil, false
+	return "", false
+}
+
+func deleteNetworkServiceName(ctx context.Context) {
+	metadata.Map(ctx, false).Delete(networkServiceNameKey{})
 }
@@ -19,6 +19,7 @@ package netsvcmonitor
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -27,39 +28,47 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/history"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
-	"github.com/ljkiraly/sdk/pkg/tools/matchutils"
 )
 
 type monitorServer struct {
 	chainCtx  context.Context
 	nsClient  registry.NetworkServiceRegistryClient
 	nseClient registry.NetworkServiceEndpointRegistryClient
+
+	mu             sync.Mutex
+	watchers       map[string]*watcher
+	cancelNSEWatch context.CancelFunc
 }
 
-// NewServer creates a new instance of netsvcmonitor server that allowes to the server chain monitor changes in the network service
+// NewServer creates a new instance of netsvcmonitor server that allowes to the server chain monitor changes in the network service.
+// All the connections to the same network service share a single registry watch, all the network services share a
+// single NSE watch.
 func NewServer(chainCtx context.Context, nsClient registry.NetworkServiceRegistryClient, nseClient registry.NetworkServiceEndpointRegistryClient) networkservice.NetworkServiceServer {
 	return &monitorServer{
 		chainCtx:  chainCtx,
 		nsClient:  nsClient,
 		nseClient: nseClient,
+		watchers:  make(map[string]*watcher),
 	}
 }
 
 func (m *monitorServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
-	if cancel, ok := loadCancelFunction(ctx); ok {
-		cancel()
-	}
+	prevName, monitored := loadNetworkServiceName(ctx)
 
 	resp, err := next.Server(ctx).Request(ctx, request)
 	if err != nil {
+		if monitored {
+			deleteNetworkServiceName(ctx)
+			m.unsubscribe(prevName, request.GetConnection().GetId(), nil)
+		}
 		return resp, err
 	}
 
-	monitorCtx, cancel := context.WithCancel(m.chainCtx)
 	minT := time.Time{}
-
 	for _, seg := range resp.GetPath().GetPathSegments() {
 		var t = seg.Expires.AsTime().Local()
 		if minT.After(t) || minT.IsZero() {
@@ -67,76 +76,111 @@ func (m *monitorServer) Request(ctx context.Context, request *networkservice.Net
 		}
 	}
 
+	conn := resp.Clone()
+	s := &subscriber{
+		conn:    conn,
+		factory: begin.FromContext(ctx),
+		history: history.Load(ctx, false),
+	}
 	if !minT.IsZero() {
-		cancel()
-		monitorCtx, cancel = context.WithTimeout(m.chainCtx, time.Until(minT))
+		clockTime := clock.FromContext(ctx)
+		s.timer = clockTime.AfterFunc(clockTime.Until(minT), func() {
+			m.unsubscribe(conn.GetNetworkService(), conn.GetId(), s)
+		})
 	}
-
-	storeCancelFunction(ctx, cancel)
-
-	go m.monitorNetworkService(monitorCtx, resp.Clone(), begin.FromContext(ctx))
+	m.subscribe(conn.GetNetworkService(), conn.GetId(), s)
+	if monitored && prevName != conn.GetNetworkService() {
+		m.unsubscribe(prevName, conn.GetId(), nil)
+	}
+	storeNetworkServiceName(ctx, conn.GetNetworkService())
 
 	return resp, err
 }
 
 func (m *monitorServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
-	if cancel, ok := loadCancelFunction(ctx); ok {
-		cancel()
+	if name, ok := loadNetworkServiceName(ctx); ok {
+		deleteNetworkServiceName(ctx)
+		m.unsubscribe(name, conn.GetId(), nil)
 	}
 
 	return next.Server(ctx).Close(ctx, conn)
 }
 
-func (m *monitorServer) monitorNetworkService(monitorCtx context.Context, conn *networkservice.Connection, factory begin.EventFactory) {
-	var logger = log.FromContext(monitorCtx).WithField("monitorServer", "Find")
-	for ; monitorCtx.Err() == nil; time.Sleep(time.Millisecond * 100) {
-		// nolint:govet
-		var stream, err = m.nsClient.Find(monitorCtx, &registry.NetworkServiceQuery{
-			Watch: true,
-			NetworkService: &registry.NetworkService{
-				Name: conn.GetNetworkService(),
+func (m *monitorServer) subscribe(name, connID string, s *subscriber) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.watchers[name]
+	if !ok {
+		if len(m.watchers) == 0 {
+			nseWatchCtx, cancel := context.WithCancel(m.chainCtx)
+			m.cancelNSEWatch = cancel
+			go m.watchNetworkServiceEndpoints(nseWatchCtx)
+		}
+		watcherCtx, cancel := context.WithCancel(m.chainCtx)
+		w = &watcher{
+			ctx:       watcherCtx,
+			cancel:    cancel,
+			name:      name,
+			nsClient:  m.nsClient,
+			nseClient: m.nseClient,
+			release: func(s *subscriber) {
+				m.unsubscribe(name, s.conn.GetId(), s)
 			},
+			subscribers: make(map[string]*subscriber),
+			nses:        make(map[string]*registry.NetworkServiceEndpoint),
+		}
+		m.watchers[name] = w
+		w.start()
+	}
+	w.subscribe(connID, s)
+}
+
+// unsubscribe - removes the connection subscriber, if s is not nil - only if it is still the current one. The watcher
+// is stopped once there are no more subscribers, the NSE watch - once there are no more watchers.
+func (m *monitorServer) unsubscribe(name, connID string, s *subscriber) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.watchers[name]
+	if !ok {
+		return
+	}
+	if w.unsubscribe(connID, s) == 0 {
+		w.cancel()
+		delete(m.watchers, name)
+		if len(m.watchers) == 0 {
+			m.cancelNSEWatch()
+		}
+	}
+}
+
+// watchNetworkServiceEndpoints - watches all the NSEs and dispatches the events to the watchers. NSEs are watched
+// without the network service filter, otherwise the NSE dropping the network service would not produce an event.
+func (m *monitorServer) watchNetworkServiceEndpoints(ctx context.Context) {
+	logger := log.FromContext(ctx).WithField("monitorServer", "watchNetworkServiceEndpoints")
+	for sleep(ctx, 0) {
+		stream, err := m.nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+			Watch:                  true,
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
 		})
 		if err != nil {
-			logger.Errorf("an error happened during finding network service: %v", err.Error())
+			logger.Errorf("an error happened during finding nse: %v", err.Error())
+			sleep(ctx, reconnectInterval)
 			continue
 		}
+		for resp := range registry.ReadNetworkServiceEndpointChannel(stream) {
+			m.mu.Lock()
+			watchers := make([]*watcher, 0, len(m.watchers))
+			for _, w := range m.watchers {
+				watchers = append(watchers, w)
+			}
+			m.mu.Unlock()
 
-		var networkServiceCh = registry.ReadNetworkServiceChannel(stream)
-		var netsvcStreamIsAlive = true
-
-		for netsvcStreamIsAlive && monitorCtx.Err() == nil {
-			select {
-			case <-monitorCtx.Done():
-				return
-			case netsvc, ok := <-networkServiceCh:
-				if !ok {
-					netsvcStreamIsAlive = false
-					break
-				}
-
-				nseStream, err := m.nseClient.Find(monitorCtx, &registry.NetworkServiceEndpointQuery{
-					NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
-						Name: conn.GetNetworkServiceEndpointName(),
-					},
-				})
-				if err != nil {
-					logger.Errorf("an error happened during finding nse: %v", err.Error())
-					break
-				}
-
-				var nses = registry.ReadNetworkServiceEndpointList(nseStream)
-
-				if len(nses) == 0 {
-					continue
-				}
-
-				if len(matchutils.MatchEndpoint(conn.GetLabels(), netsvc.GetNetworkService(), nses...)) == 0 {
-					factory.Close()
-					logger.Warnf("nse %v doesn't match with networkservice: %v", conn.GetNetworkServiceEndpointName(), conn.GetNetworkService())
-					return
-				}
+			for _, w := range watchers {
+				w.update(resp.GetNetworkServiceEndpoint(), resp.GetDeleted())
 			}
 		}
+		sleep(ctx, reconnectInterval)
 	}
 }
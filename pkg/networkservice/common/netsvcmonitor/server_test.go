@@ -19,6 +19,7 @@ package netsvcmonitor_test
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
@@ -148,3 +150,325 @@ func Test_NetsvcMonitor_ShouldNotLeakWithoutClose(t *testing.T) {
 	var _, err = server.Request(testCtx, request)
 	require.NoError(t, err)
 }
+
+type countingNSClient struct {
+	registry.NetworkServiceRegistryClient
+	watches atomic.Int32
+}
+
+func (c *countingNSClient) Find(ctx context.Context, query *registry.NetworkServiceQuery, opts ...grpc.CallOption) (registry.NetworkServiceRegistry_FindClient, error) {
+	if query.GetWatch() {
+		c.watches.Add(1)
+	}
+	return c.NetworkServiceRegistryClient.Find(ctx, query, opts...)
+}
+
+func Test_NetsvcMonitor_SharedWatchClosesOnlyAffectedConnections(t *testing.T) {
+	t.Cleanup(func() {
+		goleak.VerifyNone(t)
+	})
+
+	var testCtx, cancel = context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	var nsServer = memory.NewNetworkServiceRegistryServer()
+	var nseServer = memory.NewNetworkServiceEndpointRegistryServer()
+	var counter count.Server
+
+	_, _ = nsServer.Register(context.Background(), &registry.NetworkService{
+		Name: "service-1",
+	})
+
+	_, _ = nseServer.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "endpoint-1",
+		NetworkServiceNames: []string{"service-1"},
+	})
+
+	var nsClient = &countingNSClient{NetworkServiceRegistryClient: adapters.NetworkServiceServerToClient(nsServer)}
+	var server = chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		begin.NewServer(),
+		netsvcmonitor.NewServer(
+			testCtx,
+			nsClient,
+			adapters.NetworkServiceEndpointServerToClient(nseServer),
+		),
+		&counter,
+	)
+
+	const connCount = 10
+	for i := 0; i < connCount; i++ {
+		color := "blue"
+		if i%2 == 0 {
+			color = "red"
+		}
+		_, err := server.Request(testCtx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id:                         fmt.Sprint(i),
+				NetworkService:             "service-1",
+				NetworkServiceEndpointName: "endpoint-1",
+				Labels:                     map[string]string{"color": color},
+			},
+		})
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool {
+		return nsClient.watches.Load() == 1
+	}, time.Second, time.Millisecond*10)
+
+	// red connections don't match endpoint-1 anymore
+	_, err := nsServer.Register(context.Background(), &registry.NetworkService{
+		Name: "service-1",
+		Matches: []*registry.Match{
+			{
+				SourceSelector: map[string]string{"color": "red"},
+				Routes: []*registry.Destination{
+					{DestinationSelector: map[string]string{"app": "red-app"}},
+				},
+			},
+			{
+				Routes: []*registry.Destination{
+					{DestinationSelector: map[string]string{}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return counter.Closes() == connCount/2
+	}, time.Second, time.Millisecond*10)
+	require.Never(t, func() bool {
+		return counter.Closes() > connCount/2
+	}, time.Millisecond*300, time.Millisecond*50)
+
+	for i := 1; i < connCount; i += 2 {
+		_, err = server.Close(testCtx, &networkservice.Connection{Id: fmt.Sprint(i)})
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool {
+		return nsClient.watches.Load() == 1
+	}, time.Second, time.Millisecond*10)
+}
+
+type countingNSEClient struct {
+	registry.NetworkServiceEndpointRegistryClient
+	watches atomic.Int32
+}
+
+func (c *countingNSEClient) Find(ctx context.Context, query *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	if query.GetWatch() {
+		c.watches.Add(1)
+	}
+	return c.NetworkServiceEndpointRegistryClient.Find(ctx, query, opts...)
+}
+
+func Test_NetsvcMonitor_SharedEndpointWatch(t *testing.T) {
+	t.Cleanup(func() {
+		goleak.VerifyNone(t)
+	})
+
+	var testCtx, cancel = context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	var nsServer = memory.NewNetworkServiceRegistryServer()
+	var nseServer = memory.NewNetworkServiceEndpointRegistryServer()
+	var counter count.Server
+
+	const serviceCount = 5
+	for i := 0; i < serviceCount; i++ {
+		_, _ = nsServer.Register(context.Background(), &registry.NetworkService{
+			Name: fmt.Sprintf("service-%d", i),
+		})
+		_, _ = nseServer.Register(context.Background(), &registry.NetworkServiceEndpoint{
+			Name:                fmt.Sprintf("endpoint-%d", i),
+			NetworkServiceNames: []string{fmt.Sprintf("service-%d", i)},
+		})
+	}
+
+	var nseClient = &countingNSEClient{NetworkServiceEndpointRegistryClient: adapters.NetworkServiceEndpointServerToClient(nseServer)}
+	var server = chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		begin.NewServer(),
+		netsvcmonitor.NewServer(
+			testCtx,
+			adapters.NetworkServiceServerToClient(nsServer),
+			nseClient,
+		),
+		&counter,
+	)
+
+	for i := 0; i < serviceCount; i++ {
+		_, err := server.Request(testCtx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id:                         fmt.Sprint(i),
+				NetworkService:             fmt.Sprintf("service-%d", i),
+				NetworkServiceEndpointName: fmt.Sprintf("endpoint-%d", i),
+			},
+		})
+		require.NoError(t, err)
+	}
+	require.Never(t, func() bool {
+		return nseClient.watches.Load() > 1
+	}, time.Millisecond*300, time.Millisecond*50)
+
+	// Only the connection to the network service dropped by the NSE is closed
+	_, err := nseServer.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "endpoint-0",
+		NetworkServiceNames: []string{"service-1"},
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return counter.Closes() == 1
+	}, time.Second, time.Millisecond*10)
+	require.Never(t, func() bool {
+		return counter.Closes() > 1
+	}, time.Millisecond*300, time.Millisecond*50)
+	require.Equal(t, int32(1), nseClient.watches.Load())
+}
+
+func Test_NetsvcMonitor_ClosesConnectionsOnRemovedEndpoint(t *testing.T) {
+	t.Cleanup(func() {
+		goleak.VerifyNone(t)
+	})
+
+	var testCtx, cancel = context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	var nsServer = memory.NewNetworkServiceRegistryServer()
+	var nseServer = memory.NewNetworkServiceEndpointRegistryServer()
+	var counter count.Server
+
+	_, _ = nsServer.Register(context.Background(), &registry.NetworkService{
+		Name: "service-1",
+	})
+
+	for _, name := range []string{"endpoint-1", "endpoint-2"} {
+		_, _ = nseServer.Register(context.Background(), &registry.NetworkServiceEndpoint{
+			Name:                name,
+			NetworkServiceNames: []string{"service-1"},
+		})
+	}
+
+	var server = chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		begin.NewServer(),
+		netsvcmonitor.NewServer(
+			testCtx,
+			adapters.NetworkServiceServerToClient(nsServer),
+			adapters.NetworkServiceEndpointServerToClient(nseServer),
+		),
+		&counter,
+	)
+
+	for i, name := range []string{"endpoint-1", "endpoint-2"} {
+		_, err := server.Request(testCtx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id:                         fmt.Sprint(i),
+				NetworkService:             "service-1",
+				NetworkServiceEndpointName: name,
+			},
+		})
+		require.NoError(t, err)
+	}
+	require.Never(t, func() bool {
+		return counter.Closes() > 0
+	}, time.Millisecond*300, time.Millisecond*50)
+
+	// endpoint-1 doesn't provide service-1 anymore
+	_, err := nseServer.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "endpoint-1",
+		NetworkServiceNames: []string{"service-2"},
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return counter.Closes() == 1
+	}, time.Second, time.Millisecond*10)
+
+	// endpoint-2 is unregistered
+	_, err = nseServer.Unregister(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "endpoint-2",
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return counter.Closes() == 2
+	}, time.Second, time.Millisecond*10)
+}
+
+func Test_NetsvcMonitor_ChecksJoiningConnection(t *testing.T) {
+	t.Cleanup(func() {
+		goleak.VerifyNone(t)
+	})
+
+	var testCtx, cancel = context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	var nsServer = memory.NewNetworkServiceRegistryServer()
+	var nseServer = memory.NewNetworkServiceEndpointRegistryServer()
+	var counter count.Server
+
+	_, _ = nsServer.Register(context.Background(), &registry.NetworkService{
+		Name: "service-1",
+		Matches: []*registry.Match{
+			{
+				SourceSelector: map[string]string{"color": "red"},
+				Routes: []*registry.Destination{
+					{DestinationSelector: map[string]string{"app": "red-app"}},
+				},
+			},
+			{
+				Routes: []*registry.Destination{
+					{DestinationSelector: map[string]string{}},
+				},
+			},
+		},
+	})
+
+	_, _ = nseServer.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "endpoint-1",
+		NetworkServiceNames: []string{"service-1"},
+	})
+
+	var server = chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		begin.NewServer(),
+		netsvcmonitor.NewServer(
+			testCtx,
+			adapters.NetworkServiceServerToClient(nsServer),
+			adapters.NetworkServiceEndpointServerToClient(nseServer),
+		),
+		&counter,
+	)
+
+	request := func(id, nseName, color string) {
+		_, err := server.Request(testCtx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id:                         id,
+				NetworkService:             "service-1",
+				NetworkServiceEndpointName: nseName,
+				Labels:                     map[string]string{"color": color},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	request("1", "endpoint-1", "blue")
+	require.Never(t, func() bool {
+		return counter.Closes() > 0
+	}, time.Millisecond*300, time.Millisecond*50)
+
+	// Joins the existing watch, but endpoint-1 doesn't match red connections
+	request("2", "endpoint-1", "red")
+	require.Eventually(t, func() bool {
+		return counter.Closes() == 1
+	}, time.Second, time.Millisecond*10)
+
+	// Joins the existing watch, but endpoint-2 is not registered
+	request("3", "endpoint-2", "blue")
+	require.Eventually(t, func() bool {
+		return counter.Closes() == 2
+	}, time.Second, time.Millisecond*10)
+	require.Never(t, func() bool {
+		return counter.Closes() > 2
+	}, time.Millisecond*300, time.Millisecond*50)
+}
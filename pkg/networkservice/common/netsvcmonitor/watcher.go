@@ -0,0 +1,272 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netsvcmonitor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/history"
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/matchutils"
+)
+
+const reconnectInterval = time.Millisecond * 100
+
+type subscriber struct {
+	conn    *networkservice.Connection
+	factory begin.EventFactory
+	history *history.History
+	timer   clock.Timer
+}
+
+// watcher - a single network service watch shared by all the connections to the network service. It keeps a local
+// view of the network service endpoints, so the matches are evaluated without additional registry calls. The NSE
+// events come from the watch shared by all the watchers, see monitorServer.
+type watcher struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	name      string
+	nsClient  registry.NetworkServiceRegistryClient
+	nseClient registry.NetworkServiceEndpointRegistryClient
+	release   func(s *subscriber)
+
+	mu          sync.Mutex
+	subscribers map[string]*subscriber
+	ns          *registry.NetworkService
+	nses        map[string]*registry.NetworkServiceEndpoint
+}
+
+func (w *watcher) start() {
+	go w.watchNetworkService()
+}
+
+func (w *watcher) watchNetworkService() {
+	logger := log.FromContext(w.ctx).WithField("monitorServer", "watchNetworkService")
+	for w.sleep(0) {
+		stream, err := w.nsClient.Find(w.ctx, &registry.NetworkServiceQuery{
+			Watch: true,
+			NetworkService: &registry.NetworkService{
+				Name: w.name,
+			},
+		})
+		if err != nil {
+			logger.Errorf("an error happened during finding network service: %v", err.Error())
+			w.sleep(reconnectInterval)
+			continue
+		}
+		for resp := range registry.ReadNetworkServiceChannel(stream) {
+			if resp.GetDeleted() || resp.GetNetworkService().GetName() != w.name {
+				continue
+			}
+			w.mu.Lock()
+			w.ns = resp.GetNetworkService()
+			w.mu.Unlock()
+
+			w.check(func(*subscriber) bool { return true })
+		}
+		w.sleep(reconnectInterval)
+	}
+}
+
+// update - handles the NSE event if the NSE provides or provided the network service
+func (w *watcher) update(nse *registry.NetworkServiceEndpoint, deleted bool) {
+	selector := func(s *subscriber) bool {
+		return s.conn.GetNetworkServiceEndpointName() == nse.GetName()
+	}
+
+	w.mu.Lock()
+	_, known := w.nses[nse.GetName()]
+	if deleted || !w.provides(nse) {
+		delete(w.nses, nse.GetName())
+		w.mu.Unlock()
+
+		if known {
+			w.evict(selector, "nse %v is not registered for networkservice: %v", nse.GetName(), w.name)
+		}
+		return
+	}
+	w.nses[nse.GetName()] = nse
+	w.mu.Unlock()
+
+	w.check(selector)
+}
+
+// join - checks the new subscriber. The NSE selected for the connection is looked up in the registry once if it is
+// not in the local view yet, the connection is closed if the NSE is not registered for the network service.
+func (w *watcher) join(s *subscriber) {
+	name := s.conn.GetNetworkServiceEndpointName()
+	selector := func(current *subscriber) bool { return current == s }
+
+	w.mu.Lock()
+	_, ok := w.nses[name]
+	w.mu.Unlock()
+
+	if !ok && name != "" {
+		nse, err := w.find(name)
+		switch {
+		case err != nil:
+			log.FromContext(w.ctx).WithField("monitorServer", "join").Errorf("an error happened during finding nse: %v", err.Error())
+			return
+		case nse == nil:
+			w.evict(selector, "nse %v is not registered for networkservice: %v", name, w.name)
+			return
+		}
+		w.mu.Lock()
+		if _, ok := w.nses[name]; !ok {
+			w.nses[name] = nse
+		}
+		w.mu.Unlock()
+	}
+
+	w.check(selector)
+}
+
+// check - closes the selected connections which NSE doesn't match the network service anymore
+func (w *watcher) check(selector func(*subscriber) bool) {
+	w.mu.Lock()
+	ns := w.ns
+	w.mu.Unlock()
+
+	if ns == nil {
+		return
+	}
+
+	w.evictIf(selector, func(s *subscriber) (string, bool) {
+		w.mu.Lock()
+		nse, ok := w.nses[s.conn.GetNetworkServiceEndpointName()]
+		w.mu.Unlock()
+		if !ok || len(matchutils.MatchEndpoint(s.conn.GetLabels(), ns, nse)) > 0 {
+			return "", false
+		}
+		return fmt.Sprintf("nse %v doesn't match with networkservice: %v", nse.GetName(), ns.GetName()), true
+	})
+}
+
+// evict - closes the selected connections
+func (w *watcher) evict(selector func(*subscriber) bool, format string, v ...interface{}) {
+	w.evictIf(selector, func(*subscriber) (string, bool) {
+		return fmt.Sprintf(format, v...), true
+	})
+}
+
+// evictIf - closes the selected connections for which reason returns true
+func (w *watcher) evictIf(selector func(*subscriber) bool, reason func(*subscriber) (string, bool)) {
+	w.mu.Lock()
+	var subscribers []*subscriber
+	for _, s := range w.subscribers {
+		if s.conn.GetNetworkServiceEndpointName() != "" && selector(s) {
+			subscribers = append(subscribers, s)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, s := range subscribers {
+		msg, ok := reason(s)
+		if !ok {
+			continue
+		}
+		log.FromContext(w.ctx).WithField("monitorServer", "check").Warn(msg)
+		s.history.Add(w.ctx, history.Evicted, "%s", msg)
+		w.release(s)
+		s.factory.Close()
+	}
+}
+
+// find - returns the NSE providing the network service from the registry, nil if there is no such NSE
+func (w *watcher) find(name string) (*registry.NetworkServiceEndpoint, error) {
+	stream, err := w.nseClient.Find(w.ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			Name: name,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, nse := range registry.ReadNetworkServiceEndpointList(stream) {
+		if nse.GetName() == name && w.provides(nse) {
+			return nse, nil
+		}
+	}
+	return nil, nil
+}
+
+// provides - returns true if the NSE is registered for the network service
+func (w *watcher) provides(nse *registry.NetworkServiceEndpoint) bool {
+	for _, name := range nse.GetNetworkServiceNames() {
+		if name == w.name {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *watcher) subscribe(connID string, s *subscriber) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.removeSubscriber(connID)
+	w.subscribers[connID] = s
+
+	go w.join(s)
+}
+
+// unsubscribe - removes the subscriber (if s is not nil - only if it is the current one), returns the number of
+// the remaining ones
+func (w *watcher) unsubscribe(connID string, s *subscriber) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if current, ok := w.subscribers[connID]; ok && (s == nil || s == current) {
+		w.removeSubscriber(connID)
+	}
+	return len(w.subscribers)
+}
+
+func (w *watcher) removeSubscriber(connID string) {
+	if s, ok := w.subscribers[connID]; ok {
+		if s.timer != nil {
+			s.timer.Stop()
+		}
+		delete(w.subscribers, connID)
+	}
+}
+
+// sleep - waits for d, returns false if the watcher is stopped
+func (w *watcher) sleep(d time.Duration) bool {
+	return sleep(w.ctx, d)
+}
+
+// sleep - waits for d, returns false if ctx is done
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d == 0 {
+		return ctx.Err() == nil
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-clock.FromContext(ctx).After(d):
+		return true
+	}
+}
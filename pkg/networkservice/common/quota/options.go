@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+type options struct {
+	limits map[Kind]*Limits
+	usage  *Usage
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *options)
+
+// WithSpiffeIDLimits sets limits of active connections per client SPIFFE ID
+func WithSpiffeIDLimits(limits *Limits) Option {
+	return func(o *options) {
+		o.limits[SpiffeID] = limits
+	}
+}
+
+// WithNetworkServiceLimits sets limits of active connections per network service
+func WithNetworkServiceLimits(limits *Limits) Option {
+	return func(o *options) {
+		o.limits[NetworkService] = limits
+	}
+}
+
+// WithNetworkServiceEndpointLimits sets limits of active connections per network service endpoint
+func WithNetworkServiceEndpointLimits(limits *Limits) Option {
+	return func(o *options) {
+		o.limits[NetworkServiceEndpoint] = limits
+	}
+}
+
+// WithUsage sets Usage to keep the active connections counts in. Can be used to monitor the current usage.
+func WithUsage(usage *Usage) Option {
+	return func(o *options) {
+		o.usage = usage
+	}
+}
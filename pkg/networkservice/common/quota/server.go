@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quota provides a NetworkServiceServer chain element limiting the number of active connections per client
+// SPIFFE ID, per network service and per network service endpoint.
+package quota
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/postpone"
+	"github.com/ljkiraly/sdk/pkg/tools/spire"
+)
+
+type quotaServer struct {
+	limits map[Kind]*Limits
+	usage  *Usage
+}
+
+// NewServer returns a new quota chain element. New Requests over quota are rejected with ResourceExhausted error,
+// refreshes of the existing connections are always allowed.
+// NSE quota is applied to the NSE selected by the rest of the chain, a new connection to the full NSE is closed and
+// rejected.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		limits: make(map[Kind]*Limits),
+		usage:  NewUsage(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &quotaServer{
+		limits: o.limits,
+		usage:  o.usage,
+	}
+}
+
+func (s *quotaServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	connID := request.GetConnection().GetId()
+	isRefresh := s.usage.has(connID)
+
+	keys := make([]key, 0, 3)
+	if spiffeID, err := spire.PeerSpiffeIDFromContext(ctx); err == nil {
+		keys = append(keys, key{kind: SpiffeID, name: spiffeID.String()})
+	}
+	if ns := request.GetConnection().GetNetworkService(); ns != "" {
+		keys = append(keys, key{kind: NetworkService, name: ns})
+	}
+
+	rollback := func() {}
+	if !isRefresh {
+		var err error
+		if rollback, err = s.usage.acquire(connID, keys, s.limits, true); err != nil {
+			return nil, err
+		}
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	resp, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		rollback()
+		return nil, err
+	}
+
+	// NSE is usually selected by the rest of the chain, so its quota is checked on the response
+	if nse := resp.GetNetworkServiceEndpointName(); nse != "" {
+		keys = append(keys, key{kind: NetworkServiceEndpoint, name: nse})
+	}
+	if _, err = s.usage.acquire(connID, keys, s.limits, !isRefresh); err != nil {
+		rollback()
+
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := next.Server(ctx).Close(closeCtx, resp); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *quotaServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.usage.release(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
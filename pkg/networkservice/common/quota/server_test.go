@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/quota"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+)
+
+func request(id, ns, nse string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:                         id,
+			NetworkService:             ns,
+			NetworkServiceEndpointName: nse,
+		},
+	}
+}
+
+func TestQuotaServer_NetworkService(t *testing.T) {
+	usage := quota.NewUsage()
+	server := quota.NewServer(
+		quota.WithNetworkServiceLimits(&quota.Limits{
+			Default:   1,
+			Overrides: map[string]int{"big-ns": 2},
+		}),
+		quota.WithUsage(usage),
+	)
+	ctx := context.Background()
+
+	conn, err := server.Request(ctx, request("1", "ns", ""))
+	require.NoError(t, err)
+
+	_, err = server.Request(ctx, request("2", "ns", ""))
+	require.Error(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Refresh is allowed
+	_, err = server.Request(ctx, request("1", "ns", ""))
+	require.NoError(t, err)
+
+	for _, id := range []string{"3", "4"} {
+		_, err = server.Request(ctx, request(id, "big-ns", ""))
+		require.NoError(t, err)
+	}
+	_, err = server.Request(ctx, request("5", "big-ns", ""))
+	require.Error(t, err)
+
+	require.Equal(t, map[quota.Kind]map[string]int{
+		quota.NetworkService: {"ns": 1, "big-ns": 2},
+	}, usage.Snapshot())
+
+	_, err = server.Close(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, 0, usage.Get(quota.NetworkService, "ns"))
+
+	_, err = server.Request(ctx, request("2", "ns", ""))
+	require.NoError(t, err)
+}
+
+// selectNSEServer selects the NSE like discover does for the requests without the NSE name
+type selectNSEServer struct {
+	nse string
+}
+
+func (s *selectNSEServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if request.GetConnection().GetNetworkServiceEndpointName() == "" {
+		request.GetConnection().NetworkServiceEndpointName = s.nse
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *selectNSEServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func TestQuotaServer_NetworkServiceEndpoint(t *testing.T) {
+	usage := quota.NewUsage()
+	selectNSE := &selectNSEServer{nse: "nse-1"}
+	counter := new(count.Server)
+	server := chain.NewNetworkServiceServer(
+		quota.NewServer(
+			quota.WithNetworkServiceEndpointLimits(&quota.Limits{Default: 1}),
+			quota.WithUsage(usage),
+		),
+		selectNSE,
+		counter,
+	)
+	ctx := context.Background()
+
+	// NSE selected by the rest of the chain is counted
+	conn, err := server.Request(ctx, request("1", "ns", ""))
+	require.NoError(t, err)
+	require.Equal(t, 1, usage.Get(quota.NetworkServiceEndpoint, "nse-1"))
+
+	// New connection to the full NSE is closed and rejected
+	_, err = server.Request(ctx, request("2", "ns", ""))
+	require.Error(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, 1, counter.Closes())
+
+	selectNSE.nse = "nse-2"
+	_, err = server.Request(ctx, request("2", "ns", ""))
+	require.NoError(t, err)
+
+	// Refreshes are never rejected, even to the full NSE
+	_, err = server.Request(ctx, request("1", "ns", "nse-1"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, request("1", "ns", "nse-2"))
+	require.NoError(t, err)
+	require.Equal(t, 0, usage.Get(quota.NetworkServiceEndpoint, "nse-1"))
+	require.Equal(t, 2, usage.Get(quota.NetworkServiceEndpoint, "nse-2"))
+
+	conn.NetworkServiceEndpointName = "nse-2"
+	_, err = server.Close(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, 1, usage.Get(quota.NetworkServiceEndpoint, "nse-2"))
+}
+
+func TestQuotaServer_FailedRequest(t *testing.T) {
+	usage := quota.NewUsage()
+	server := chain.NewNetworkServiceServer(
+		quota.NewServer(
+			quota.WithNetworkServiceLimits(&quota.Limits{Default: 1}),
+			quota.WithUsage(usage),
+		),
+		injecterror.NewServer(),
+	)
+
+	_, err := server.Request(context.Background(), request("1", "ns", ""))
+	require.Error(t, err)
+	require.Empty(t, usage.Snapshot())
+}
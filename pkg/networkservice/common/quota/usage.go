@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Kind is a kind of the quota key
+type Kind string
+
+const (
+	// SpiffeID - connections of the client identity
+	SpiffeID Kind = "spiffe_id"
+	// NetworkService - connections to the network service
+	NetworkService Kind = "network_service"
+	// NetworkServiceEndpoint - connections to the network service endpoint
+	NetworkServiceEndpoint Kind = "network_service_endpoint"
+)
+
+// Limits are the maximum active connections per key. Zero value means no limit.
+type Limits struct {
+	// Default is applied to all the keys not presented in Overrides
+	Default int
+	// Overrides are per key limits
+	Overrides map[string]int
+}
+
+func (l *Limits) get(name string) int {
+	if l == nil {
+		return 0
+	}
+	if limit, ok := l.Overrides[name]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+type key struct {
+	kind Kind
+	name string
+}
+
+// Usage keeps the active connections count per key. It is safe for concurrent use.
+type Usage struct {
+	mu          sync.Mutex
+	connections map[string][]key
+	counts      map[key]int
+}
+
+// NewUsage creates a new empty Usage
+func NewUsage() *Usage {
+	return &Usage{
+		connections: make(map[string][]key),
+		counts:      make(map[key]int),
+	}
+}
+
+// Get returns the number of active connections for name of kind
+func (u *Usage) Get(kind Kind, name string) int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.counts[key{kind: kind, name: name}]
+}
+
+// Snapshot returns the numbers of active connections for all the keys
+func (u *Usage) Snapshot() map[Kind]map[string]int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	rv := make(map[Kind]map[string]int)
+	for k, count := range u.counts {
+		if rv[k.kind] == nil {
+			rv[k.kind] = make(map[string]int)
+		}
+		rv[k.kind][k.name] = count
+	}
+	return rv
+}
+
+// has returns true if the connection holds the keys
+func (u *Usage) has(connID string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	_, ok := u.connections[connID]
+	return ok
+}
+
+// acquire replaces keys of the connection with newKeys. If check is set, it fails if any of the limits is exceeded.
+// Keys already held by the connection are not checked.
+// Returns function restoring the previous keys of the connection.
+func (u *Usage) acquire(connID string, newKeys []key, limits map[Kind]*Limits, check bool) (rollback func(), err error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	oldKeys, isHeld := u.connections[connID]
+	held := make(map[key]struct{}, len(oldKeys))
+	for _, k := range oldKeys {
+		held[k] = struct{}{}
+	}
+
+	for _, k := range newKeys {
+		if _, ok := held[k]; ok || !check {
+			continue
+		}
+		if limit := limits[k.kind].get(k.name); limit > 0 && u.counts[k] >= limit {
+			return nil, status.Errorf(codes.ResourceExhausted, "connection quota exceeded for %s %s: %d active connections", k.kind, k.name, limit)
+		}
+	}
+
+	u.set(connID, newKeys)
+
+	return func() {
+		u.mu.Lock()
+		defer u.mu.Unlock()
+
+		if isHeld {
+			u.set(connID, oldKeys)
+		} else {
+			u.set(connID, nil)
+		}
+	}, nil
+}
+
+func (u *Usage) release(connID string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.set(connID, nil)
+}
+
+func (u *Usage) set(connID string, keys []key) {
+	for _, k := range u.connections[connID] {
+		if u.counts[k]--; u.counts[k] <= 0 {
+			delete(u.counts, k)
+		}
+	}
+	if keys == nil {
+		delete(u.connections, connID)
+		return
+	}
+	for _, k := range keys {
+		u.counts[k]++
+	}
+	u.connections[connID] = keys
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides a NetworkServiceServer chain element limiting the rate and the concurrency of the
+// incoming Requests
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/ratelimit"
+	"github.com/ljkiraly/sdk/pkg/tools/spire"
+)
+
+type rateLimitServer struct {
+	limiter *ratelimit.Limiter
+}
+
+// NewServer returns a new rate limiting chain element. Throttled Requests are rejected with ResourceExhausted error.
+// Requests are keyed by the peer SPIFFE ID, the network service and the network service endpoint names. Empty keys
+// (e.g. the endpoint name before the endpoint is selected) are not limited.
+// Closes are never throttled.
+func NewServer(limiter *ratelimit.Limiter) networkservice.NetworkServiceServer {
+	return &rateLimitServer{
+		limiter: limiter,
+	}
+}
+
+func (s *rateLimitServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	keys := map[ratelimit.KeyType]string{
+		ratelimit.NetworkService:         request.GetConnection().GetNetworkService(),
+		ratelimit.NetworkServiceEndpoint: request.GetConnection().GetNetworkServiceEndpointName(),
+	}
+	if spiffeID, err := spire.PeerSpiffeIDFromContext(ctx); err == nil {
+		keys[ratelimit.SpiffeID] = spiffeID.String()
+	}
+
+	release, err := s.limiter.Acquire(clock.FromContext(ctx).Now(), keys)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *rateLimitServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
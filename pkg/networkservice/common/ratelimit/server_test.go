@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/ratelimit"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/clockmock"
+	ratelimittools "github.com/ljkiraly/sdk/pkg/tools/ratelimit"
+)
+
+func TestRateLimitServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	limiter, err := ratelimittools.NewLimiter(&ratelimittools.Config{
+		Rules: []*ratelimittools.Rule{
+			{Key: ratelimittools.NetworkService, Pattern: "limited-.*", Rate: 1, Burst: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	counter := new(count.Server)
+	server := chain.NewNetworkServiceServer(
+		ratelimit.NewServer(limiter),
+		counter,
+	)
+
+	request := func(ns string) error {
+		_, requestErr := server.Request(ctx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id:             ns,
+				NetworkService: ns,
+			},
+		})
+		return requestErr
+	}
+
+	require.NoError(t, request("limited-ns"))
+	err = request("limited-ns")
+	require.Error(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.NoError(t, request("ns"))
+	require.NoError(t, request("ns"))
+
+	_, err = server.Close(ctx, &networkservice.Connection{Id: "limited-ns", NetworkService: "limited-ns"})
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.Closes())
+
+	clockMock.Add(time.Second)
+	require.NoError(t, request("limited-ns"))
+	require.Equal(t, 4, counter.Requests())
+}
+
+func TestRateLimitServer_EmptyEndpointName(t *testing.T) {
+	limiter, err := ratelimittools.NewLimiter(&ratelimittools.Config{
+		Rules: []*ratelimittools.Rule{
+			{Key: ratelimittools.NetworkServiceEndpoint, Rate: 1, Burst: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	server := ratelimit.NewServer(limiter)
+
+	request := func(id, nse string) error {
+		_, requestErr := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id:                         id,
+				NetworkServiceEndpointName: nse,
+			},
+		})
+		return requestErr
+	}
+
+	// Requests with the endpoint not selected yet don't share a bucket
+	require.NoError(t, request("conn-1", ""))
+	require.NoError(t, request("conn-2", ""))
+
+	require.NoError(t, request("conn-1", "nse"))
+	err = request("conn-2", "nse")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
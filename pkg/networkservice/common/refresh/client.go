@@ -30,22 +30,31 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/history"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
 	"github.com/ljkiraly/sdk/pkg/tools/clock"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/refreshutils"
 )
 
+const lagKind = "connection"
+
 type refreshClient struct {
 	chainCtx context.Context
+	options
 }
 
 // NewClient - creates new NetworkServiceClient chain element for refreshing
 // connections before they timeout at the endpoint.
-func NewClient(ctx context.Context) networkservice.NetworkServiceClient {
-	return &refreshClient{
+func NewClient(ctx context.Context, opts ...Option) networkservice.NetworkServiceClient {
+	c := &refreshClient{
 		chainCtx: ctx,
 	}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	return c
 }
 
 func (t *refreshClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
@@ -57,7 +66,8 @@ func (t *refreshClient) Request(ctx context.Context, request *networkservice.Net
 	}
 
 	// Compute refreshAfter
-	refreshAfter := after(ctx, conn)
+	refreshAfter, expireAfter := after(ctx, conn)
+	refreshAfter = refreshutils.Jitter(refreshAfter, t.jitter)
 
 	// Create a cancel context.
 	cancelCtx, cancel := context.WithCancel(t.chainCtx)
@@ -68,18 +78,27 @@ func (t *refreshClient) Request(ctx context.Context, request *networkservice.Net
 	store(ctx, metadata.IsClient(t), cancel)
 
 	eventFactory := begin.FromContext(ctx)
+	connHistory := history.Load(ctx, metadata.IsClient(t))
 	// Create the afterCh *outside* the go routine.  This must be done to avoid picking up a later 'now'
 	// from mockClock in testing
-	afterCh := clock.FromContext(ctx).After(refreshAfter)
+	clockTime := clock.FromContext(ctx)
+	scheduled := clockTime.Now().Add(refreshAfter)
+	deadline := scheduled.Add((expireAfter - refreshAfter) / 2)
+	afterCh := clockTime.After(refreshAfter)
 	go func() {
 		for {
 			select {
 			case <-cancelCtx.Done():
 				return
 			case <-afterCh:
+				if _, err := t.limiter.Wait(clock.WithClock(cancelCtx, clockTime), deadline); err != nil {
+					return
+				}
+				refreshutils.RecordLag(ctx, lagKind, clockTime.Since(scheduled))
 				if err := <-eventFactory.Request(begin.CancelContext(cancelCtx)); err != nil {
 					afterCh = clock.FromContext(ctx).After(time.Millisecond * 200)
 					logger.Warnf("refresh failed: %s", err.Error())
+					connHistory.Add(ctx, history.RefreshFailed, "%s", err.Error())
 					continue
 				}
 				return
@@ -97,7 +116,8 @@ func (t *refreshClient) Close(ctx context.Context, conn *networkservice.Connecti
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
 
-func after(ctx context.Context, conn *networkservice.Connection) time.Duration {
+// after returns the refresh and the expiration durations
+func after(ctx context.Context, conn *networkservice.Connection) (refreshAfter, expireAfter time.Duration) {
 	clockTime := clock.FromContext(ctx)
 
 	var minTimeout *time.Duration
@@ -122,7 +142,7 @@ func after(ctx context.Context, conn *networkservice.Connection) time.Duration {
 	}
 
 	if minTimeout == nil || *minTimeout <= 0 {
-		return 1
+		return 1, 1
 	}
 
 	// A heuristic to reduce the number of redundant requests in a chain
@@ -136,5 +156,5 @@ func after(ctx context.Context, conn *networkservice.Connection) time.Duration {
 	}
 	duration := time.Duration(float64(*minTimeout) * scale)
 
-	return duration
+	return duration, *minTimeout
 }
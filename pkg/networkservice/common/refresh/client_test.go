@@ -44,6 +44,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
 	"github.com/ljkiraly/sdk/pkg/tools/clock"
 	"github.com/ljkiraly/sdk/pkg/tools/clockmock"
+	"github.com/ljkiraly/sdk/pkg/tools/refreshutils"
 	"github.com/ljkiraly/sdk/pkg/tools/sandbox"
 	"github.com/ljkiraly/sdk/pkg/tools/token"
 )
@@ -459,3 +460,46 @@ func TestRefreshClient_NoRetryOnRefreshSuccess(t *testing.T) {
 
 	require.Never(t, cloneClient.validator(3), testWait, testTick)
 }
+
+func TestRefreshClient_Limiter(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+
+	counter := new(countutil.Client)
+	client := next.NewNetworkServiceClient(
+		updatepath.NewClient("refresh"),
+		begin.NewClient(),
+		metadata.NewClient(),
+		injectclock.NewClient(clockMock),
+		refresh.NewClient(ctx, refresh.WithLimiter(refreshutils.NewLimiter(1))),
+		adapters.NewServerToClient(
+			updatetoken.NewServer(testTokenFunc(clockMock)),
+		),
+		counter,
+	)
+
+	for _, id := range []string{"id-1", "id-2"} {
+		_, err := client.Request(ctx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: id,
+			},
+		})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, counter.Requests())
+
+	// Both refreshes are scheduled at the same time, the limiter lets only one of them through
+	clockMock.Add(expireTimeout / 3)
+	require.Eventually(t, func() bool { return counter.Requests() == 3 }, testWait, testTick)
+	require.Never(t, func() bool { return counter.Requests() > 3 }, testWait, testTick)
+
+	// The second one is delayed to the next slot
+	require.Eventually(t, func() bool {
+		clockMock.Add(time.Second / 10)
+		return counter.Requests() == 4
+	}, testWait*10, testTick)
+}
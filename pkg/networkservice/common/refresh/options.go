@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refresh
+
+import (
+	"github.com/ljkiraly/sdk/pkg/tools/refreshutils"
+)
+
+type options struct {
+	jitter  float64
+	limiter *refreshutils.Limiter
+}
+
+// Option is an option pattern for NewClient
+type Option func(*options)
+
+// WithJitter sets the fraction (0..1] by which each refresh is randomly scheduled earlier. Default: 0
+func WithJitter(jitter float64) Option {
+	return func(o *options) {
+		o.jitter = jitter
+	}
+}
+
+// WithLimiter sets the refresh rate limiter. The same limiter should be shared by all the refresh clients in the
+// process. The refresh waits for the limiter no longer than the half of the time left to the expiration.
+func WithLimiter(limiter *refreshutils.Limiter) Option {
+	return func(o *options) {
+		o.limiter = limiter
+	}
+}
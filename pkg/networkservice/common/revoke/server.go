@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package revoke provides a NetworkServiceServer chain element that rejects requests with revoked tokens or from revoked
+// peers and closes already established connections once their tokens or peers get revoked.
+package revoke
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
+	"github.com/ljkiraly/sdk/pkg/tools/spire"
+)
+
+type connectionInfo struct {
+	prevID  string
+	tokens  []string
+	factory begin.EventFactory
+}
+
+type revokeServer struct {
+	ctx                   context.Context
+	list                  *revocation.List
+	spiffeIDConnectionMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]
+	connections           genericsync.Map[string, *connectionInfo]
+}
+
+// NewServer - returns a new revoke chain element. It checks the left side of the Path against list on each Request
+// and closes the matching connections each time list is changed. spiffeIDConnectionMap should be shared with the
+// authorize chain element, it is used to close the connections of the revoked peers.
+// Should be placed after begin and authorize chain elements.
+func NewServer(ctx context.Context, list *revocation.List, spiffeIDConnectionMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]) networkservice.NetworkServiceServer {
+	if list == nil {
+		panic("list cannot be nil")
+	}
+	if spiffeIDConnectionMap == nil {
+		panic("spiffeIDConnectionMap cannot be nil")
+	}
+
+	s := &revokeServer{
+		ctx:                   ctx,
+		list:                  list,
+		spiffeIDConnectionMap: spiffeIDConnectionMap,
+	}
+
+	updateCh := list.Subscribe(ctx)
+	go func() {
+		for range updateCh {
+			s.closeRevoked()
+		}
+	}()
+
+	return s
+}
+
+func (s *revokeServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if spiffeID, err := spire.PeerSpiffeIDFromContext(ctx); err == nil && s.list.IsSpiffeIDRevoked(spiffeID.String()) {
+		return nil, status.Errorf(codes.PermissionDenied, "spiffe id %s is revoked", spiffeID.String())
+	}
+
+	path := request.GetConnection().GetPath()
+	index := path.GetIndex()
+	var tokens []string
+	for _, segment := range path.GetPathSegments()[:index+1] {
+		if err := s.list.CheckToken(segment.GetToken()); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, segment.GetToken())
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &connectionInfo{
+		tokens:  tokens,
+		factory: begin.FromContext(ctx),
+	}
+	if index > 0 {
+		info.prevID = path.GetPathSegments()[index-1].GetId()
+	}
+	s.connections.Store(conn.GetId(), info)
+
+	return conn, nil
+}
+
+func (s *revokeServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.connections.Delete(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *revokeServer) closeRevoked() {
+	revokedConnIDs := make(map[string]struct{})
+	for _, rawID := range s.list.SpiffeIDs() {
+		id, err := spiffeid.FromString(rawID)
+		if err != nil {
+			continue
+		}
+		if ids, ok := s.spiffeIDConnectionMap.Load(id); ok {
+			ids.Range(func(connID string, _ struct{}) bool {
+				revokedConnIDs[connID] = struct{}{}
+				return true
+			})
+		}
+	}
+
+	s.connections.Range(func(id string, info *connectionInfo) bool {
+		if !s.isRevoked(info, revokedConnIDs) {
+			return true
+		}
+		log.FromContext(s.ctx).WithField("revokeServer", "closeRevoked").Warnf("closing revoked connection: %s", id)
+		s.connections.Delete(id)
+		info.factory.Close()
+		return true
+	})
+}
+
+func (s *revokeServer) isRevoked(info *connectionInfo, revokedConnIDs map[string]struct{}) bool {
+	if _, ok := revokedConnIDs[info.prevID]; ok && info.prevID != "" {
+		return true
+	}
+	for _, token := range info.tokens {
+		if s.list.CheckToken(token) != nil {
+			return true
+		}
+	}
+	return false
+}
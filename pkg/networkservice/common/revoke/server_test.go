@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revoke_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/revoke"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
+)
+
+func genJWT(id, subject string) string {
+	t, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.RegisteredClaims{
+		ID:      id,
+		Subject: subject,
+	}).SignedString([]byte("super secret"))
+	return t
+}
+
+func newRequest(connID, tokenID string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: connID,
+			Path: &networkservice.Path{
+				Index: 1,
+				PathSegments: []*networkservice.PathSegment{
+					{Id: "nsc-" + connID, Token: genJWT(tokenID, "spiffe://test.com/nsc")},
+					{Id: connID, Token: genJWT(tokenID+"-nsmgr", "spiffe://test.com/nsmgr")},
+				},
+			},
+		},
+	}
+}
+
+func TestRevokeServer_RejectsRevokedTokens(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	list := revocation.NewList(nil, []string{"token-1"})
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		revoke.NewServer(ctx, list, new(genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]])),
+	)
+
+	_, err := server.Request(ctx, newRequest("1", "token-1"))
+	require.Error(t, err)
+
+	_, err = server.Request(ctx, newRequest("2", "token-2"))
+	require.NoError(t, err)
+}
+
+func TestRevokeServer_ClosesRevokedConnections(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	counter := new(count.Server)
+	list := revocation.NewList(nil, nil)
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		revoke.NewServer(ctx, list, new(genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]])),
+		counter,
+	)
+
+	_, err := server.Request(ctx, newRequest("1", "token-1"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, newRequest("2", "token-2"))
+	require.NoError(t, err)
+
+	list.Revoke(nil, []string{"token-1"})
+	require.Eventually(t, func() bool { return counter.Closes() == 1 }, time.Second, time.Millisecond*10)
+	require.Never(t, func() bool { return counter.Closes() > 1 }, time.Millisecond*100, time.Millisecond*10)
+
+	list.Revoke([]string{"spiffe://test.com/nsc"}, nil)
+	require.Eventually(t, func() bool { return counter.Closes() == 2 }, time.Second, time.Millisecond*10)
+}
+
+func TestRevokeServer_ClosesConnectionsOfRevokedPeer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerID := spiffeid.RequireFromString("spiffe://test.com/peer")
+	connIDs := new(genericsync.Map[string, struct{}])
+	connIDs.Store("nsc-1", struct{}{})
+	spiffeIDConnectionMap := new(genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]])
+	spiffeIDConnectionMap.Store(peerID, connIDs)
+
+	counter := new(count.Server)
+	list := revocation.NewList(nil, nil)
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		revoke.NewServer(ctx, list, spiffeIDConnectionMap),
+		counter,
+	)
+
+	_, err := server.Request(ctx, newRequest("1", "token-1"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, newRequest("2", "token-2"))
+	require.NoError(t, err)
+
+	list.Revoke([]string{peerID.String()}, nil)
+	require.Eventually(t, func() bool { return counter.Closes() == 1 }, time.Second, time.Millisecond*10)
+}
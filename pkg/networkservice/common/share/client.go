@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package share provides opt-in NetworkServiceClient chain elements sharing a single underlying connection between
+// identical requests.
+//
+// Requests with the same network service, payload, NSE name, labels, mechanism preferences with their parameters and
+// requested connection context reference-count one underlying connection, which is closed only when the last user
+// closes its connection. Each user keeps its own connection ID. NewClient should be placed at the head of the client
+// chain, NewMonitorClient - after begin, so that the connection changes made by refresh and heal are fanned out to all
+// the users via Pool.MonitorConnections.
+package share
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+)
+
+type shareClient struct {
+	pool *Pool
+}
+
+// NewClient - returns a new client chain element sharing the connections from pool
+func NewClient(pool *Pool) networkservice.NetworkServiceClient {
+	return &shareClient{
+		pool: pool,
+	}
+}
+
+func (c *shareClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	userID := request.GetConnection().GetId()
+	if userID == "" {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+
+	for {
+		if e, ok := c.pool.user(userID); ok {
+			if conn, err := c.wait(ctx, e, userID); err == nil {
+				return conn, nil
+			} else if ctx.Err() != nil {
+				return nil, err
+			}
+			// The shared connection has failed or has been closed, request it again
+			_, _ = c.pool.leave(userID)
+		}
+
+		e, first := c.pool.join(key(request), userID)
+		if !first {
+			conn, err := c.wait(ctx, e, userID)
+			if err != nil && ctx.Err() != nil {
+				_, _ = c.pool.leave(userID)
+				return nil, err
+			}
+			if err != nil {
+				continue
+			}
+			return conn, nil
+		}
+
+		shared := request.Clone()
+		shared.GetConnection().Id = uuid.New().String()
+		if segment := shared.GetConnection().GetCurrentPathSegment(); segment != nil {
+			segment.Id = shared.GetConnection().GetId()
+		}
+
+		// begin keeps the request context values for refresh and heal, so the monitor client finds the entry by it
+		client := next.Client(ctx)
+		conn, err := client.Request(withEntry(ctx, e), shared, opts...)
+		c.pool.established(e, client, conn, err)
+		if err != nil {
+			return nil, err
+		}
+		return view(conn, userID), nil
+	}
+}
+
+func (c *shareClient) wait(ctx context.Context, e *entry, userID string) (*networkservice.Connection, error) {
+	select {
+	case <-ctx.Done():
+		return nil, errors.Wrapf(ctx.Err(), "failed to wait for the shared connection for %s", userID)
+	case <-e.ready:
+		return c.pool.current(e, userID)
+	}
+}
+
+func (c *shareClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if conn.GetId() == "" {
+		return next.Client(ctx).Close(ctx, conn, opts...)
+	}
+
+	e, last := c.pool.leave(conn.GetId())
+	if !last {
+		return &empty.Empty{}, nil
+	}
+
+	<-e.ready
+	shared := c.pool.sharedConn(e)
+	if shared == nil {
+		return &empty.Empty{}, nil
+	}
+	return e.client.Close(ctx, shared, opts...)
+}
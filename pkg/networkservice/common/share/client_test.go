@@ -0,0 +1,280 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package share_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/share"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
+)
+
+func newRequest(id, color string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             id,
+			NetworkService: "ns",
+			Labels:         map[string]string{"color": color},
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Cls: "LOCAL", Type: kernel.MECHANISM},
+		},
+	}
+}
+
+func TestShareClient_RefCount(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	pool := share.NewPool()
+	counter := new(count.Client)
+	client := chain.NewNetworkServiceClient(
+		share.NewClient(pool),
+		begin.NewClient(),
+		share.NewMonitorClient(pool),
+		counter,
+	)
+
+	var wg sync.WaitGroup
+	conns := make([]*networkservice.Connection, 3)
+	for i, id := range []string{"user-1", "user-2", "user-3"} {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			conn, err := client.Request(ctx, newRequest(id, "red"))
+			require.NoError(t, err)
+			conns[i] = conn
+		}(i, id)
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, counter.Requests())
+	require.Equal(t, "user-1", conns[0].GetId())
+	require.Equal(t, "user-2", conns[1].GetId())
+
+	// Different labels - different connection
+	blue, err := client.Request(ctx, newRequest("user-4", "blue"))
+	require.NoError(t, err)
+	require.Equal(t, 2, counter.Requests())
+
+	// Different local endpoint - different connection
+	otherNetNS := newRequest("user-5", "red")
+	otherNetNS.GetMechanismPreferences()[0].Parameters = map[string]string{
+		common.InodeURL:         "file:///proc/1/ns/net",
+		common.InterfaceNameKey: "nsm-1",
+	}
+	otherNetNSConn, err := client.Request(ctx, otherNetNS)
+	require.NoError(t, err)
+	require.Equal(t, 3, counter.Requests())
+
+	// Refresh of the user connection doesn't request the shared one
+	_, err = client.Request(ctx, &networkservice.NetworkServiceRequest{Connection: conns[0]})
+	require.NoError(t, err)
+	require.Equal(t, 3, counter.Requests())
+
+	for _, conn := range conns[:2] {
+		_, err = client.Close(ctx, conn)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 0, counter.Closes())
+
+	_, err = client.Close(ctx, conns[2])
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.Closes())
+
+	_, err = client.Close(ctx, blue)
+	require.NoError(t, err)
+	require.Equal(t, 2, counter.Closes())
+
+	_, err = client.Close(ctx, otherNetNSConn)
+	require.NoError(t, err)
+	require.Equal(t, 3, counter.Closes())
+
+	// The last user has closed the shared connection, the new one is requested
+	_, err = client.Request(ctx, newRequest("user-1", "red"))
+	require.NoError(t, err)
+	require.Equal(t, 4, counter.Requests())
+	_, err = client.Close(ctx, &networkservice.Connection{Id: "user-1"})
+	require.NoError(t, err)
+}
+
+func TestShareClient_MonitorFanOut(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	pool := share.NewPool()
+	var factory begin.EventFactory
+	client := chain.NewNetworkServiceClient(
+		share.NewClient(pool),
+		begin.NewClient(),
+		share.NewMonitorClient(pool),
+		checkcontext.NewClient(t, func(_ *testing.T, ctx context.Context) {
+			factory = begin.FromContext(ctx)
+		}),
+	)
+
+	_, err := client.Request(ctx, newRequest("user-1", "red"))
+	require.NoError(t, err)
+	_, err = client.Request(ctx, newRequest("user-2", "red"))
+	require.NoError(t, err)
+
+	stream, err := adapters.NewMonitorServerToClient(pool).MonitorConnections(ctx, &networkservice.MonitorScopeSelector{})
+	require.NoError(t, err)
+
+	event, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, event.GetType())
+	require.Len(t, event.GetConnections(), 2)
+
+	// Refresh of the shared connection is fanned out to all the users
+	require.NoError(t, <-factory.Request())
+	event, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+	require.Contains(t, event.GetConnections(), "user-1")
+	require.Contains(t, event.GetConnections(), "user-2")
+
+	// Close of the shared connection from the chain is fanned out as well
+	require.NoError(t, <-factory.Close())
+	event, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_DELETE, event.GetType())
+	require.Len(t, event.GetConnections(), 2)
+}
+
+// idsClient records IDs of the requested and closed connections
+type idsClient struct {
+	mu        sync.Mutex
+	requested []string
+	closed    []string
+}
+
+func (c *idsClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	c.mu.Lock()
+	c.requested = append(c.requested, request.GetConnection().GetId())
+	c.mu.Unlock()
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *idsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.mu.Lock()
+	c.closed = append(c.closed, conn.GetId())
+	c.mu.Unlock()
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func (c *idsClient) lastRequested() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.requested[len(c.requested)-1]
+}
+
+func (c *idsClient) closedIDs() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.closed...)
+}
+
+func TestShareClient_Reselect(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	pool := share.NewPool()
+	var factory begin.EventFactory
+	ids := new(idsClient)
+	client := chain.NewNetworkServiceClient(
+		share.NewClient(pool),
+		begin.NewClient(),
+		share.NewMonitorClient(pool),
+		checkcontext.NewClient(t, func(_ *testing.T, ctx context.Context) {
+			factory = begin.FromContext(ctx)
+		}),
+		ids,
+	)
+
+	conn1, err := client.Request(ctx, newRequest("user-1", "red"))
+	require.NoError(t, err)
+	conn2, err := client.Request(ctx, newRequest("user-2", "red"))
+	require.NoError(t, err)
+	sharedID := ids.lastRequested()
+
+	stream, err := adapters.NewMonitorServerToClient(pool).MonitorConnections(ctx, &networkservice.MonitorScopeSelector{})
+	require.NoError(t, err)
+	event, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, event.GetType())
+
+	// Heal closes the shared connection and requests it again, the users keep it
+	require.NoError(t, <-factory.Request(begin.WithReselect()))
+	for _, eventType := range []networkservice.ConnectionEventType{
+		networkservice.ConnectionEventType_DELETE,
+		networkservice.ConnectionEventType_UPDATE,
+	} {
+		event, err = stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, eventType, event.GetType())
+		require.Len(t, event.GetConnections(), 2)
+	}
+	require.Equal(t, []string{sharedID}, ids.closedIDs())
+
+	conn1, err = client.Request(ctx, &networkservice.NetworkServiceRequest{Connection: conn1})
+	require.NoError(t, err)
+
+	// Make-before-break reselect changes the shared connection ID, the previous one is closed silently
+	require.NoError(t, <-factory.Request(begin.WithReselect(), begin.WithMakeBeforeBreak(alive)))
+	newSharedID := ids.lastRequested()
+	require.NotEqual(t, sharedID, newSharedID)
+	event, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+	require.Len(t, event.GetConnections(), 2)
+	require.Equal(t, []string{sharedID, sharedID}, ids.closedIDs())
+
+	// The last user closes the healed shared connection
+	_, err = client.Close(ctx, conn1)
+	require.NoError(t, err)
+	_, err = client.Close(ctx, conn2)
+	require.NoError(t, err)
+	require.Equal(t, []string{sharedID, sharedID, newSharedID}, ids.closedIDs())
+}
+
+func alive(context.Context, *networkservice.Connection) bool {
+	return true
+}
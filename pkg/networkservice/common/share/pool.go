@@ -0,0 +1,326 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package share
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+const subscriberBufferSize = 64
+
+type entry struct {
+	key    string
+	client networkservice.NetworkServiceClient
+	conn   *networkservice.Connection
+	users  map[string]struct{}
+	// ready is closed once the underlying connection is requested, err is the request error
+	ready chan struct{}
+	err   error
+}
+
+type subscriber struct {
+	ids map[string]struct{}
+	ch  chan *networkservice.ConnectionEvent
+}
+
+// Pool keeps the shared connections. The same Pool should be used by all the clients sharing the connections.
+//
+// Pool implements networkservice.MonitorConnectionServer streaming the shared connection events to each of its users
+// with the user connection IDs, so it can be registered with a local agent gRPC server.
+type Pool struct {
+	mu          sync.Mutex
+	entries     map[string]*entry
+	users       map[string]*entry
+	shared      map[string]*entry
+	subscribers map[*subscriber]struct{}
+}
+
+// NewPool - returns a new Pool
+func NewPool() *Pool {
+	return &Pool{
+		entries:     make(map[string]*entry),
+		users:       make(map[string]*entry),
+		shared:      make(map[string]*entry),
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// key returns the sharing key of the request: identical requests have the same key. Mechanism parameters (e.g. the
+// netns URL and the interface name) and the requested connection context are a part of the key, so the users with
+// different local endpoints never share a connection.
+func key(request *networkservice.NetworkServiceRequest) string {
+	conn := request.GetConnection()
+
+	mechanisms := make([]string, 0, len(request.GetMechanismPreferences()))
+	for _, m := range request.GetMechanismPreferences() {
+		mechanisms = append(mechanisms, m.GetCls()+"/"+m.GetType()+"{"+join(m.GetParameters())+"}")
+	}
+
+	// Deterministic marshaling keeps the map fields ordered, so the equal contexts have the same bytes
+	connectionContext, _ := proto.MarshalOptions{Deterministic: true}.Marshal(conn.GetContext())
+
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%x",
+		conn.GetNetworkService(),
+		conn.GetPayload(),
+		conn.GetNetworkServiceEndpointName(),
+		join(conn.GetLabels()),
+		strings.Join(mechanisms, ","),
+		connectionContext)
+}
+
+// join returns the sorted key=value pairs of m
+func join(m map[string]string) string {
+	rv := make([]string, 0, len(m))
+	for k, v := range m {
+		rv = append(rv, k+"="+v)
+	}
+	sort.Strings(rv)
+	return strings.Join(rv, ",")
+}
+
+// view returns the shared connection as seen by the user
+func view(conn *networkservice.Connection, userID string) *networkservice.Connection {
+	rv := conn.Clone()
+	rv.Id = userID
+	return rv
+}
+
+// join adds the user to the entry with the key. Returns the entry and true if the user is the first one and has to
+// request the underlying connection.
+func (p *Pool) join(k, userID string) (*entry, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if e, ok := p.entries[k]; ok {
+		e.users[userID] = struct{}{}
+		p.users[userID] = e
+		return e, false
+	}
+
+	e := &entry{
+		key:   k,
+		users: map[string]struct{}{userID: {}},
+		ready: make(chan struct{}),
+	}
+	p.entries[k] = e
+	p.users[userID] = e
+	return e, true
+}
+
+// established stores the result of the underlying connection request and wakes up the waiting users
+func (p *Pool) established(e *entry, client networkservice.NetworkServiceClient, conn *networkservice.Connection, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e.client, e.err = client, err
+	if err == nil {
+		e.conn = conn.Clone()
+		p.shared[conn.GetId()] = e
+	} else {
+		p.removeLocked(e)
+	}
+	close(e.ready)
+}
+
+// user returns the entry of the user
+func (p *Pool) user(userID string) (*entry, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, ok := p.users[userID]
+	return e, ok
+}
+
+// leave removes the user from its entry. Returns the entry if the user was the last one and the underlying connection
+// has to be closed.
+func (p *Pool) leave(userID string) (*entry, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, ok := p.users[userID]
+	if !ok {
+		return nil, false
+	}
+	delete(p.users, userID)
+	delete(e.users, userID)
+	if len(e.users) > 0 {
+		return e, false
+	}
+	p.removeLocked(e)
+	return e, true
+}
+
+// current returns the user view of the shared connection
+func (p *Pool) current(e *entry, userID string) (*networkservice.Connection, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if e.err != nil {
+		return nil, e.err
+	}
+	if e.conn == nil {
+		return nil, errors.Errorf("shared connection for %s is closed", userID)
+	}
+	return view(e.conn, userID), nil
+}
+
+// sharedConn returns the underlying connection of the entry, nil if it is closed
+func (p *Pool) sharedConn(e *entry) *networkservice.Connection {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if e.conn == nil {
+		return nil
+	}
+	return e.conn.Clone()
+}
+
+func (p *Pool) removeLocked(e *entry) {
+	if p.entries[e.key] == e {
+		delete(p.entries, e.key)
+	}
+	if e.conn != nil && p.shared[e.conn.GetId()] == e {
+		delete(p.shared, e.conn.GetId())
+	}
+	for userID := range e.users {
+		if p.users[userID] == e {
+			delete(p.users, userID)
+		}
+	}
+}
+
+// update stores the updated underlying connection and sends the UPDATE event to its users. The entry is found by
+// the context of the shared connection request, so the connection ID changed by reselect is followed.
+func (p *Pool) update(e *entry, conn *networkservice.Connection) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if e == nil {
+		e = p.shared[conn.GetId()]
+	}
+	if e == nil || e.conn == nil || p.entries[e.key] != e {
+		return
+	}
+	if prevID := e.conn.GetId(); prevID != conn.GetId() {
+		delete(p.shared, prevID)
+		p.shared[conn.GetId()] = e
+	}
+	e.conn = conn.Clone()
+	p.publishLocked(networkservice.ConnectionEventType_UPDATE, e)
+}
+
+// closed sends the DELETE event to the users of the closed underlying connection. The entry is kept until the last
+// user leaves: the chain closes the connection on reselect and requests it again, and a close of the previous
+// connection after the make-before-break reselect is not reported at all.
+func (p *Pool) closed(e *entry, conn *networkservice.Connection) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if e == nil {
+		e = p.shared[conn.GetId()]
+	}
+	if e == nil || e.conn == nil || p.entries[e.key] != e || e.conn.GetId() != conn.GetId() {
+		return
+	}
+	p.publishLocked(networkservice.ConnectionEventType_DELETE, e)
+}
+
+func (p *Pool) publishLocked(eventType networkservice.ConnectionEventType, e *entry) {
+	for s := range p.subscribers {
+		event := &networkservice.ConnectionEvent{
+			Type:        eventType,
+			Connections: make(map[string]*networkservice.Connection),
+		}
+		for userID := range e.users {
+			if s.matches(userID) {
+				event.Connections[userID] = view(e.conn, userID)
+			}
+		}
+		if len(event.Connections) == 0 {
+			continue
+		}
+		select {
+		case s.ch <- event:
+		default:
+		}
+	}
+}
+
+func (s *subscriber) matches(userID string) bool {
+	if len(s.ids) == 0 {
+		return true
+	}
+	_, ok := s.ids[userID]
+	return ok
+}
+
+// MonitorConnections streams the shared connection events for the user connections from the selector path segments.
+// Empty selector selects all the users.
+func (p *Pool) MonitorConnections(selector *networkservice.MonitorScopeSelector, srv networkservice.MonitorConnection_MonitorConnectionsServer) error {
+	s := &subscriber{
+		ids: make(map[string]struct{}),
+		ch:  make(chan *networkservice.ConnectionEvent, subscriberBufferSize),
+	}
+	for _, segment := range selector.GetPathSegments() {
+		if segment.GetId() != "" {
+			s.ids[segment.GetId()] = struct{}{}
+		}
+	}
+
+	p.mu.Lock()
+	initial := &networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER,
+		Connections: make(map[string]*networkservice.Connection),
+	}
+	for userID, e := range p.users {
+		if e.conn != nil && s.matches(userID) {
+			initial.Connections[userID] = view(e.conn, userID)
+		}
+	}
+	p.subscribers[s] = struct{}{}
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		delete(p.subscribers, s)
+		p.mu.Unlock()
+	}()
+
+	if err := srv.Send(initial); err != nil {
+		return errors.Wrap(err, "failed to send initial state transfer")
+	}
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case event := <-s.ch:
+			if err := srv.Send(event); err != nil {
+				return errors.Wrap(err, "failed to send connection event")
+			}
+		}
+	}
+}
+
+var _ networkservice.MonitorConnectionServer = (*Pool)(nil)
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package standby provides a NetworkServiceClient chain element keeping a pre-established standby connection to
+// a different NSE for each primary connection.
+//
+// On reselect the standby connection is promoted to primary instead of the full discover and Request cycle, and a new
+// standby connection is built in background. The promotion requires make-before-break reselect (see
+// heal.WithMakeBeforeBreak), otherwise the standby connection is closed together with the primary one.
+package standby
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+type standbyClient struct {
+	chainCtx  context.Context
+	nsClient  registry.NetworkServiceRegistryClient
+	nseClient registry.NetworkServiceEndpointRegistryClient
+	options
+	groups genericsync.Map[begin.EventFactory, *group]
+}
+
+// NewClient - returns a new standby chain element. nsClient and nseClient are used to select the NSE for the standby
+// connection. Should be placed right after begin.
+func NewClient(chainCtx context.Context, nsClient registry.NetworkServiceRegistryClient, nseClient registry.NetworkServiceEndpointRegistryClient, opts ...Option) networkservice.NetworkServiceClient {
+	s := &standbyClient{
+		chainCtx:  chainCtx,
+		nsClient:  nsClient,
+		nseClient: nseClient,
+	}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	return s
+}
+
+func (s *standbyClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	factory := begin.FromContext(ctx)
+
+	g, loaded := s.groups.Load(factory)
+	if loaded && request.GetConnection().GetState() == networkservice.State_RESELECT_REQUESTED {
+		if conn := g.promote(); conn != nil {
+			log.FromContext(ctx).WithField("standbyClient", "Request").Infof("standby connection %s is promoted", conn.GetId())
+			g.ensureStandby()
+			return conn, nil
+		}
+	}
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !loaded {
+		g = &group{
+			chainCtx:       s.chainCtx,
+			primaryFactory: factory,
+			selectEndpoint: s.selectEndpoint,
+		}
+		s.groups.Store(factory, g)
+	}
+	g.update(ctx, request, conn, opts)
+	g.ensureStandby()
+
+	return conn, nil
+}
+
+func (s *standbyClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	factory := begin.FromContext(ctx)
+	if g, ok := s.groups.Load(factory); ok && g.isPrimary(conn.GetId()) {
+		s.groups.Delete(factory)
+		g.close()
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standby_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/standby"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/registry/common/memory"
+	registryadapters "github.com/ljkiraly/sdk/pkg/registry/core/adapters"
+)
+
+const (
+	nsName      = "ns"
+	domainLabel = "zone"
+)
+
+func registryClients(t *testing.T, nses ...*registry.NetworkServiceEndpoint) (registry.NetworkServiceRegistryClient, registry.NetworkServiceEndpointRegistryClient) {
+	nsClient := registryadapters.NetworkServiceServerToClient(memory.NewNetworkServiceRegistryServer())
+	_, err := nsClient.Register(context.Background(), &registry.NetworkService{Name: nsName})
+	require.NoError(t, err)
+
+	nseClient := registryadapters.NetworkServiceEndpointServerToClient(memory.NewNetworkServiceEndpointRegistryServer())
+	for _, nse := range nses {
+		_, err = nseClient.Register(context.Background(), nse)
+		require.NoError(t, err)
+	}
+	return nsClient, nseClient
+}
+
+func endpoint(name, zone string) *registry.NetworkServiceEndpoint {
+	return &registry.NetworkServiceEndpoint{
+		Name:                name,
+		NetworkServiceNames: []string{nsName},
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			nsName: {Labels: map[string]string{domainLabel: zone}},
+		},
+	}
+}
+
+func TestStandbyClient_Promote(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	nsClient, nseClient := registryClients(t,
+		endpoint("nse-1", "a"),
+		endpoint("nse-2", "a"),
+		endpoint("nse-3", "b"),
+	)
+
+	nsm := newNSMClient()
+	client := chain.NewNetworkServiceClient(
+		begin.NewClient(),
+		standby.NewClient(ctx, nsClient, nseClient, standby.WithFailureDomainLabel(domainLabel)),
+		nsm,
+	)
+
+	conn, err := client.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "1",
+			NetworkService: nsName,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "nse-1", conn.GetNetworkServiceEndpointName())
+
+	// The standby connection is established to the NSE from the other failure domain
+	var standbyID string
+	require.Eventually(t, func() bool {
+		standbyID = nsm.connectionTo("nse-3")
+		return standbyID != ""
+	}, time.Second, time.Millisecond*10)
+	require.NotEqual(t, conn.GetId(), standbyID)
+
+	// Reselect promotes the standby connection without requesting a new one
+	requests := nsm.requestCount()
+	require.NoError(t, <-begin.FromContext(nsm.context(conn.GetId())).Request(begin.WithReselect(), begin.WithMakeBeforeBreak(alive)))
+	require.True(t, nsm.isClosed(conn.GetId()))
+
+	// A new standby connection is built in background
+	require.Eventually(t, func() bool {
+		return nsm.connectionTo("nse-1") != "" && nsm.requestCount() == requests+1
+	}, time.Second, time.Millisecond*10)
+	newStandbyID := nsm.connectionTo("nse-1")
+
+	// Refresh with the old connection is applied to the promoted one
+	conn, err = client.Request(ctx, &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	require.Equal(t, standbyID, conn.GetId())
+	require.Equal(t, "nse-3", conn.GetNetworkServiceEndpointName())
+
+	// Close closes both the primary and the standby connections
+	_, err = client.Close(ctx, conn)
+	require.NoError(t, err)
+	require.True(t, nsm.isClosed(standbyID))
+	require.Eventually(t, func() bool { return nsm.isClosed(newStandbyID) }, time.Second, time.Millisecond*10)
+}
+
+func TestStandbyClient_NoCandidates(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	nsClient, nseClient := registryClients(t, endpoint("nse-1", "a"))
+
+	nsm := newNSMClient()
+	client := chain.NewNetworkServiceClient(
+		begin.NewClient(),
+		standby.NewClient(ctx, nsClient, nseClient, standby.WithFailureDomainLabel(domainLabel)),
+		nsm,
+	)
+
+	conn, err := client.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "1",
+			NetworkService: nsName,
+		},
+	})
+	require.NoError(t, err)
+	require.Never(t, func() bool { return nsm.requestCount() > 1 }, time.Millisecond*100, time.Millisecond*10)
+
+	// Without standby connection reselect requests a new connection
+	require.NoError(t, <-begin.FromContext(nsm.context(conn.GetId())).Request(begin.WithReselect(), begin.WithMakeBeforeBreak(alive)))
+	require.Equal(t, 2, nsm.requestCount())
+	require.True(t, nsm.isClosed(conn.GetId()))
+
+	_, err = client.Close(ctx, conn)
+	require.NoError(t, err)
+}
+
+// nsmClient - selects "nse-1" if no NSE is requested and records the connections
+type nsmClient struct {
+	mu       sync.Mutex
+	requests int
+	contexts map[string]context.Context
+	nses     map[string]string
+	closed   map[string]bool
+}
+
+func newNSMClient() *nsmClient {
+	return &nsmClient{
+		contexts: make(map[string]context.Context),
+		nses:     make(map[string]string),
+		closed:   make(map[string]bool),
+	}
+}
+
+func (c *nsmClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn := request.GetConnection().Clone()
+	if conn.GetNetworkServiceEndpointName() == "" {
+		conn.NetworkServiceEndpointName = "nse-1"
+	}
+
+	c.mu.Lock()
+	c.requests++
+	c.contexts[conn.GetId()] = ctx
+	c.nses[conn.GetId()] = conn.GetNetworkServiceEndpointName()
+	c.mu.Unlock()
+
+	return next.Client(ctx).Request(ctx, &networkservice.NetworkServiceRequest{Connection: conn}, opts...)
+}
+
+func (c *nsmClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.mu.Lock()
+	c.closed[conn.GetId()] = true
+	c.mu.Unlock()
+
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func (c *nsmClient) requestCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.requests
+}
+
+func (c *nsmClient) context(id string) context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.contexts[id]
+}
+
+func (c *nsmClient) connectionTo(nseName string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, name := range c.nses {
+		if name == nseName && !c.closed[id] {
+			return id
+		}
+	}
+	return ""
+}
+
+func (c *nsmClient) isClosed(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed[id]
+}
+
+func alive(context.Context, *networkservice.Connection) bool {
+	return true
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standby
+
+import (
+	"context"
+	"sync"
+
+	"github.com/edwarnicke/serialize"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/postpone"
+)
+
+// group - the primary connection and its standby
+type group struct {
+	chainCtx       context.Context
+	primaryFactory begin.EventFactory
+	selectEndpoint func(ctx context.Context, conn *networkservice.Connection) (string, error)
+	executor       serialize.Executor
+
+	mu        sync.Mutex
+	client    networkservice.NetworkServiceClient
+	ctxFunc   func() (context.Context, context.CancelFunc)
+	request   *networkservice.NetworkServiceRequest
+	opts      []grpc.CallOption
+	primaryID string
+	standby   *standbyConn
+	building  bool
+	closed    bool
+}
+
+func (g *group) update(ctx context.Context, request *networkservice.NetworkServiceRequest, conn *networkservice.Connection, opts []grpc.CallOption) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.client = next.Client(ctx)
+	g.ctxFunc = postpone.ContextWithValues(ctx)
+	g.request = request.Clone()
+	g.request.Connection = conn.Clone()
+	g.opts = opts
+	g.primaryID = conn.GetId()
+}
+
+func (g *group) isPrimary(id string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.primaryID == id
+}
+
+// ensureStandby - starts building a new standby connection in background if there is no one
+func (g *group) ensureStandby() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed || g.building || g.standby != nil {
+		return
+	}
+	g.building = true
+	g.executor.AsyncExec(g.build)
+}
+
+func (g *group) build() {
+	g.mu.Lock()
+	request, client, ctxFunc, opts := g.request.Clone(), g.client, g.ctxFunc, g.opts
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		g.building = false
+		g.mu.Unlock()
+	}()
+
+	if g.chainCtx.Err() != nil {
+		return
+	}
+
+	ctx, cancel := ctxFunc()
+	defer cancel()
+
+	logger := log.FromContext(ctx).WithField("standbyClient", "build")
+
+	nseName, err := g.selectEndpoint(ctx, request.GetConnection())
+	if err != nil {
+		logger.Warnf("failed to select standby endpoint: %s", err.Error())
+		return
+	}
+
+	sc := &standbyConn{
+		g:       g,
+		client:  client,
+		ctxFunc: ctxFunc,
+		opts:    opts,
+	}
+	request.Connection = standbyConnection(request.GetConnection(), nseName)
+
+	conn, err := client.Request(begin.WithEventFactory(ctx, sc), request, opts...)
+	if err != nil {
+		logger.Warnf("failed to establish standby connection to %s: %s", nseName, err.Error())
+		return
+	}
+	sc.request = request
+	sc.request.Connection = conn.Clone()
+
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		_, _ = client.Close(begin.WithEventFactory(ctx, sc), conn, opts...)
+		return
+	}
+	g.standby = sc
+	g.mu.Unlock()
+
+	logger.Infof("standby connection %s to %s is established", conn.GetId(), nseName)
+}
+
+// promote - makes the standby connection primary and returns it, returns nil if there is no standby connection
+func (g *group) promote() *networkservice.Connection {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	sc := g.standby
+	if sc == nil {
+		return nil
+	}
+	g.standby = nil
+	sc.promoted = true
+
+	g.request = sc.request.Clone()
+	g.primaryID = sc.request.GetConnection().GetId()
+
+	return sc.request.GetConnection().Clone()
+}
+
+// drop - forgets sc if it is still the standby connection, returns true if it was
+func (g *group) drop(sc *standbyConn) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.standby != sc {
+		return false
+	}
+	g.standby = nil
+	return true
+}
+
+func (g *group) close() {
+	g.mu.Lock()
+	g.closed = true
+	sc := g.standby
+	g.standby = nil
+	g.mu.Unlock()
+
+	if sc != nil {
+		g.executor.AsyncExec(sc.closeConnection)
+	}
+}
+
+// standbyConn - the standby connection. It is an EventFactory for the chain elements below standby, so they can
+// refresh and heal it independently of the primary one. Once promoted, all the events are passed to the primary
+// EventFactory.
+type standbyConn struct {
+	g        *group
+	client   networkservice.NetworkServiceClient
+	ctxFunc  func() (context.Context, context.CancelFunc)
+	opts     []grpc.CallOption
+	request  *networkservice.NetworkServiceRequest
+	promoted bool
+}
+
+func (sc *standbyConn) isPromoted() bool {
+	sc.g.mu.Lock()
+	defer sc.g.mu.Unlock()
+
+	return sc.promoted
+}
+
+func (sc *standbyConn) Request(opts ...begin.Option) <-chan error {
+	if sc.isPromoted() {
+		return sc.g.primaryFactory.Request(opts...)
+	}
+	ch := make(chan error, 1)
+	sc.g.executor.AsyncExec(func() {
+		defer close(ch)
+
+		sc.g.mu.Lock()
+		isStandby := sc.g.standby == sc
+		sc.g.mu.Unlock()
+		if !isStandby {
+			return
+		}
+
+		ctx, cancel := sc.ctxFunc()
+		defer cancel()
+
+		conn, err := sc.client.Request(begin.WithEventFactory(ctx, sc), sc.request.Clone(), sc.opts...)
+		if err != nil {
+			// The standby connection is broken, replace it with a new one
+			log.FromContext(ctx).WithField("standbyClient", "Request").Warnf("standby connection failed: %s", err.Error())
+			if sc.g.drop(sc) {
+				_, _ = sc.client.Close(begin.WithEventFactory(ctx, sc), sc.request.GetConnection(), sc.opts...)
+			}
+			sc.g.ensureStandby()
+			return
+		}
+		sc.request.Connection = conn.Clone()
+	})
+	return ch
+}
+
+func (sc *standbyConn) Close(opts ...begin.Option) <-chan error {
+	if sc.isPromoted() {
+		return sc.g.primaryFactory.Close(opts...)
+	}
+	ch := make(chan error, 1)
+	sc.g.executor.AsyncExec(func() {
+		defer close(ch)
+		if sc.g.drop(sc) {
+			sc.closeConnection()
+		}
+	})
+	return ch
+}
+
+func (sc *standbyConn) closeConnection() {
+	ctx, cancel := sc.ctxFunc()
+	defer cancel()
+
+	_, _ = sc.client.Close(begin.WithEventFactory(ctx, sc), sc.request.GetConnection(), sc.opts...)
+}
+
+// standbyConnection - returns a copy of conn for the standby connection to nseName. The current path segment gets a
+// new ID and the next ones are dropped, so the next hops treat it as a new connection.
+func standbyConnection(conn *networkservice.Connection, nseName string) *networkservice.Connection {
+	rv := conn.Clone()
+	rv.Id = uuid.New().String()
+	rv.NetworkServiceEndpointName = nseName
+	rv.Mechanism = nil
+	rv.State = networkservice.State_UP
+
+	path := rv.GetPath()
+	if int(path.GetIndex()) < len(path.GetPathSegments()) {
+		path.PathSegments = path.GetPathSegments()[:path.GetIndex()+1]
+		path.GetPathSegments()[path.GetIndex()].Id = rv.GetId()
+	}
+	return rv
+}
+
+var _ begin.EventFactory = &standbyConn{}
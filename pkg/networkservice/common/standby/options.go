@@ -0,0 +1,32 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standby
+
+type options struct {
+	failureDomainLabel string
+}
+
+// Option is an option pattern for NewClient
+type Option func(*options)
+
+// WithFailureDomainLabel sets the NSE label defining its failure domain. The standby connection prefers NSEs with
+// a failure domain different from the primary one.
+func WithFailureDomainLabel(label string) Option {
+	return func(o *options) {
+		o.failureDomainLabel = label
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standby
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/drainutils"
+	"github.com/ljkiraly/sdk/pkg/tools/matchutils"
+)
+
+// selectEndpoint - returns the name of an NSE to establish the standby connection to. It should differ from the NSE
+// of the primary connection and preferably be in a different failure domain.
+func (s *standbyClient) selectEndpoint(ctx context.Context, conn *networkservice.Connection) (string, error) {
+	nsStream, err := s.nsClient.Find(ctx, &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{
+			Name: conn.GetNetworkService(),
+		},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find network service %s", conn.GetNetworkService())
+	}
+	var ns *registry.NetworkService
+	for _, item := range registry.ReadNetworkServiceList(nsStream) {
+		if item.GetName() == conn.GetNetworkService() {
+			ns = item
+		}
+	}
+	if ns == nil {
+		return "", errors.Errorf("network service %s is not found", conn.GetNetworkService())
+	}
+
+	nseStream, err := s.nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			NetworkServiceNames: []string{ns.GetName()},
+		},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find endpoints for %s", ns.GetName())
+	}
+	nses := registry.ReadNetworkServiceEndpointList(nseStream)
+
+	primaryDomain, hasPrimaryDomain := "", false
+	for _, nse := range nses {
+		if nse.GetName() == conn.GetNetworkServiceEndpointName() {
+			primaryDomain, hasPrimaryDomain = s.failureDomain(nse, ns.GetName())
+		}
+	}
+
+	var candidates []*registry.NetworkServiceEndpoint
+	now := clock.FromContext(ctx).Now()
+	for _, nse := range matchutils.MatchEndpoint(conn.GetLabels(), ns, drainutils.Filter(nses)...) {
+		if nse.GetName() == conn.GetNetworkServiceEndpointName() {
+			continue
+		}
+		if nse.GetExpirationTime() != nil && !nse.GetExpirationTime().AsTime().After(now) {
+			continue
+		}
+		candidates = append(candidates, nse)
+	}
+	if len(candidates) == 0 {
+		return "", errors.Errorf("no standby endpoint candidates found for %s", ns.GetName())
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].GetName() < candidates[j].GetName()
+	})
+
+	if hasPrimaryDomain {
+		for _, nse := range candidates {
+			if domain, ok := s.failureDomain(nse, ns.GetName()); !ok || domain != primaryDomain {
+				return nse.GetName(), nil
+			}
+		}
+	}
+	return candidates[0].GetName(), nil
+}
+
+func (s *standbyClient) failureDomain(nse *registry.NetworkServiceEndpoint, nsName string) (string, bool) {
+	if s.failureDomainLabel == "" {
+		return "", false
+	}
+	domain, ok := nse.GetNetworkServiceLabels()[nsName].GetLabels()[s.failureDomainLabel]
+	return domain, ok
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/history"
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
 	"github.com/ljkiraly/sdk/pkg/tools/clock"
 
@@ -74,6 +75,7 @@ func (s *timeoutServer) Request(ctx context.Context, request *networkservice.Net
 	}
 	store(ctx, metadata.IsClient(s), cancel)
 	eventFactory := begin.FromContext(ctx)
+	connHistory := history.Load(ctx, metadata.IsClient(s))
 	afterCh := timeClock.After(timeClock.Until(expirationTime) - requestTimeout)
 
 	go func(cancelCtx context.Context, afterCh <-chan time.Time) {
@@ -81,6 +83,7 @@ func (s *timeoutServer) Request(ctx context.Context, request *networkservice.Net
 		select {
 		case <-cancelCtx.Done():
 		case <-afterCh:
+			connHistory.Add(ctx, history.Timeout, "connection expired at %s", expirationTime.UTC())
 			<-eventFactory.Close(begin.CancelContext(cancelCtx))
 		}
 	}(cancelCtx, afterCh)
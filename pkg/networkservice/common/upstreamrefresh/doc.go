@@ -15,5 +15,5 @@
 // limitations under the License.
 
 // Package upstreamrefresh provides a client chain element that receives monitor connectionEvents
-// and processes those that have refresh_requested state
+// and processes those that have refresh_requested or reselect_requested state
 package upstreamrefresh
@@ -88,11 +88,16 @@ func (cev *eventLoop) eventLoop() {
 	}
 
 	select {
-	case _, ok := <-upstreamCh:
+	case state, ok := <-upstreamCh:
 		if !ok {
 			// Connection closed
 			return
 		}
+		if state == networkservice.State_RESELECT_REQUESTED {
+			cev.logger.Debug("reselect requested from upstream")
+			<-cev.eventFactory.Request(begin.WithReselect())
+			return
+		}
 		cev.logger.Debug("refresh requested from upstream")
 
 		<-cev.eventFactory.Request()
@@ -110,8 +115,8 @@ func (cev *eventLoop) eventLoop() {
 	}
 }
 
-func (cev *eventLoop) monitorUpstream() <-chan struct{} {
-	res := make(chan struct{}, 1)
+func (cev *eventLoop) monitorUpstream() <-chan networkservice.State {
+	res := make(chan networkservice.State, 1)
 
 	go func() {
 		defer close(res)
@@ -123,8 +128,9 @@ func (cev *eventLoop) monitorUpstream() <-chan struct{} {
 			}
 
 			// Handle event
-			if eventIn.GetConnections()[cev.conn.GetId()].GetState() == networkservice.State_REFRESH_REQUESTED {
-				res <- struct{}{}
+			switch state := eventIn.GetConnections()[cev.conn.GetId()].GetState(); state {
+			case networkservice.State_REFRESH_REQUESTED, networkservice.State_RESELECT_REQUESTED:
+				res <- state
 				return
 			}
 		}
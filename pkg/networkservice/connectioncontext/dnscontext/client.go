@@ -27,11 +27,13 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/miekg/dns"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/postpone"
 )
 
 type dnsContextClient struct {
@@ -40,9 +42,13 @@ type dnsContextClient struct {
 	defaultNameServerIP string
 	resolvconfDNSConfig *networkservice.DNSConfig
 	dnsConfigsMap       *genericsync.Map[string, []*networkservice.DNSConfig]
+	linkWriter          LinkConfigWriter
+	links               genericsync.Map[string, *linkState]
 }
 
 // NewClient creates a new DNS client chain component. Setups all DNS traffic to the localhost. Monitors DNS configs from connections.
+// With WithLinkConfigWriter it leaves resolv.conf untouched and instead applies DNS configs of each connection to
+// the kernel interface of the connection only.
 func NewClient(options ...DNSOption) networkservice.NetworkServiceClient {
 	var c = &dnsContextClient{
 		chainContext:        context.Background(),
@@ -53,7 +59,9 @@ func NewClient(options ...DNSOption) networkservice.NetworkServiceClient {
 		o.apply(c)
 	}
 
-	c.initialize()
+	if c.linkWriter == nil {
+		c.initialize()
+	}
 
 	return c
 }
@@ -71,20 +79,91 @@ func (c *dnsContextClient) Request(ctx context.Context, request *networkservice.
 		request.Connection.Context.DnsContext = &networkservice.DNSContext{}
 	}
 
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
 	rv, err := next.Client(ctx).Request(ctx, request, opts...)
 	if err != nil {
 		return nil, err
 	}
 
+	if c.linkWriter != nil {
+		_, loaded := c.links.Load(rv.GetId())
+		if err = c.configureLink(ctx, rv); err != nil {
+			if !loaded {
+				if restoreErr := c.restoreLink(ctx, rv.GetId()); restoreErr != nil {
+					log.FromContext(ctx).Errorf("failed to restore DNS config: %s", restoreErr.Error())
+				}
+
+				closeCtx, cancelClose := postponeCtxFunc()
+				defer cancelClose()
+
+				if _, closeErr := next.Client(ctx).Close(closeCtx, rv, opts...); closeErr != nil {
+					err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+				}
+			}
+			return nil, err
+		}
+		if c.dnsConfigsMap != nil {
+			c.dnsConfigsMap.Store(rv.Id, rv.GetContext().GetDnsContext().GetConfigs())
+		}
+		return rv, nil
+	}
+
 	c.dnsConfigsMap.Store(rv.Id, append(rv.GetContext().GetDnsContext().Configs, c.resolvconfDNSConfig))
 	return rv, nil
 }
 
 func (c *dnsContextClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
-	c.dnsConfigsMap.Delete(conn.Id)
+	if c.linkWriter != nil {
+		if err := c.restoreLink(ctx, conn.GetId()); err != nil {
+			log.FromContext(ctx).Errorf("failed to restore DNS config: %s", err.Error())
+		}
+	}
+	if c.dnsConfigsMap != nil {
+		c.dnsConfigsMap.Delete(conn.Id)
+	}
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
 
+// configureLink applies DNS configs of the connection to its kernel interface. The interface configuration is saved
+// on the first request to be restored on Close.
+func (c *dnsContextClient) configureLink(ctx context.Context, conn *networkservice.Connection) error {
+	ifName := kernel.ToMechanism(conn.GetMechanism()).GetInterfaceName()
+
+	state, ok := c.links.Load(conn.GetId())
+	if ok && state.ifName != ifName {
+		if err := c.restoreLink(ctx, conn.GetId()); err != nil {
+			return err
+		}
+		ok = false
+	}
+	if ifName == "" {
+		return nil
+	}
+
+	if !ok {
+		original, err := c.linkWriter.Read(ctx, ifName)
+		if err != nil {
+			return err
+		}
+		c.links.Store(conn.GetId(), &linkState{
+			ifName:   ifName,
+			original: original,
+		})
+	}
+
+	return c.linkWriter.Write(ctx, ifName, newLinkConfig(conn.GetContext().GetDnsContext().GetConfigs()))
+}
+
+// restoreLink restores the interface configuration saved for the connection.
+func (c *dnsContextClient) restoreLink(ctx context.Context, connID string) error {
+	state, ok := c.links.LoadAndDelete(connID)
+	if !ok {
+		return nil
+	}
+	return c.linkWriter.Write(ctx, state.ifName, state.original)
+}
+
 func (c *dnsContextClient) restoreResolvConf() {
 	bytes, err := os.ReadFile(c.resolveConfigPath)
 	if err != nil {
@@ -28,6 +28,7 @@ import (
 
 	"github.com/edwarnicke/genericsync"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
 
@@ -84,6 +85,82 @@ nameserver 127.0.0.1`
 	require.NoError(t, err)
 }
 
+func Test_DNSContextClient_LinkConfigWriter(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	dir := t.TempDir()
+	resolveConfigPath := filepath.Join(dir, "resolv.conf")
+	const resolvConf = "nameserver 8.8.4.4\n"
+	require.NoError(t, os.WriteFile(resolveConfigPath, []byte(resolvConf), os.ModePerm))
+
+	const originalLinkConf = "[Resolve]\nDNS=10.0.0.53\nDomains=corp.local\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nsm-2.conf"), []byte(originalLinkConf), os.ModePerm))
+
+	client := chain.NewNetworkServiceClient(
+		metadata.NewClient(),
+		dnscontext.NewClient(
+			dnscontext.WithChainContext(ctx),
+			dnscontext.WithResolveConfigPath(resolveConfigPath),
+			dnscontext.WithLinkConfigWriter(dnscontext.NewFileLinkConfigWriter(dir)),
+		),
+	)
+
+	newRequest := func(id, ifName string, configs ...*networkservice.DNSConfig) *networkservice.NetworkServiceRequest {
+		mechanism := kernel.New("")
+		kernel.ToMechanism(mechanism).SetInterfaceName(ifName)
+		return &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id:        id,
+				Mechanism: mechanism,
+				Context: &networkservice.ConnectionContext{
+					DnsContext: &networkservice.DNSContext{
+						Configs: configs,
+					},
+				},
+			},
+		}
+	}
+
+	conn1, err := client.Request(ctx, newRequest("nsc-1", "nsm-1",
+		&networkservice.DNSConfig{DnsServerIps: []string{"172.16.0.1"}, SearchDomains: []string{"red.svc"}},
+		&networkservice.DNSConfig{DnsServerIps: []string{"172.16.0.1", "172.16.0.2"}, SearchDomains: []string{"red.local"}},
+	))
+	require.NoError(t, err)
+
+	conn2, err := client.Request(ctx, newRequest("nsc-2", "nsm-2",
+		&networkservice.DNSConfig{DnsServerIps: []string{"192.168.0.1"}, SearchDomains: []string{"blue.svc"}},
+	))
+	require.NoError(t, err)
+
+	requireFileContent(t, resolveConfigPath, resolvConf)
+	requireFileContent(t, filepath.Join(dir, "nsm-1.conf"), "[Resolve]\nDNS=172.16.0.1 172.16.0.2\nDomains=red.svc red.local\n")
+	requireFileContent(t, filepath.Join(dir, "nsm-2.conf"), "[Resolve]\nDNS=192.168.0.1\nDomains=blue.svc\n")
+
+	// Refresh with the updated configs keeps the original configuration for Close
+	conn2, err = client.Request(ctx, newRequest(conn2.GetId(), "nsm-2",
+		&networkservice.DNSConfig{DnsServerIps: []string{"192.168.0.2"}},
+	))
+	require.NoError(t, err)
+	requireFileContent(t, filepath.Join(dir, "nsm-2.conf"), "[Resolve]\nDNS=192.168.0.2\n")
+
+	_, err = client.Close(ctx, conn1)
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "nsm-1.conf"))
+	require.True(t, os.IsNotExist(err))
+
+	_, err = client.Close(ctx, conn2)
+	require.NoError(t, err)
+	requireFileContent(t, filepath.Join(dir, "nsm-2.conf"), originalLinkConf)
+}
+
+func requireFileContent(t *testing.T, location, expected string) {
+	b, err := os.ReadFile(filepath.Clean(location))
+	require.NoError(t, err)
+	require.Equal(t, expected, string(b))
+}
+
 func requireFileChanged(ctx context.Context, t *testing.T, location, expected string) {
 	var r string
 	for ctx.Err() == nil {
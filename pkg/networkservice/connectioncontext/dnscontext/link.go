@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscontext
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// LinkConfig is a split DNS configuration of a single network interface.
+type LinkConfig struct {
+	// DNSServers is a list of DNS servers used for the queries routed to the interface
+	DNSServers []string
+	// Domains is a list of search domains of the interface
+	Domains []string
+}
+
+// IsEmpty returns true if the interface has no DNS configuration.
+func (c *LinkConfig) IsEmpty() bool {
+	return c == nil || len(c.DNSServers) == 0 && len(c.Domains) == 0
+}
+
+// LinkConfigWriter reads and writes per-interface DNS configuration in the systemd-resolved style.
+// Writing an empty LinkConfig reverts the interface to having no DNS configuration.
+type LinkConfigWriter interface {
+	Read(ctx context.Context, ifName string) (*LinkConfig, error)
+	Write(ctx context.Context, ifName string, config *LinkConfig) error
+}
+
+// linkState is an interface configured for a connection together with its configuration before the connection.
+type linkState struct {
+	ifName   string
+	original *LinkConfig
+}
+
+// newLinkConfig merges DNS configs of a connection into a single interface configuration.
+func newLinkConfig(configs []*networkservice.DNSConfig) *LinkConfig {
+	result := new(LinkConfig)
+	for _, config := range configs {
+		result.DNSServers = appendUnique(result.DNSServers, config.GetDnsServerIps()...)
+		result.Domains = appendUnique(result.Domains, config.GetSearchDomains()...)
+	}
+	return result
+}
+
+func appendUnique(values []string, items ...string) []string {
+	for _, item := range items {
+		if item != "" && !containsNameserver(values, item) {
+			values = append(values, item)
+		}
+	}
+	return values
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscontext
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	linkConfigSection = "[Resolve]"
+	linkDNSKey        = "DNS"
+	linkDomainsKey    = "Domains"
+)
+
+type fileLinkConfigWriter struct {
+	dir string
+}
+
+// NewFileLinkConfigWriter creates a LinkConfigWriter keeping the configuration of each interface in a separate
+// <dir>/<ifName>.conf file:
+//
+//	[Resolve]
+//	DNS=10.0.0.1 10.0.0.2
+//	Domains=example.com
+//
+// An interface with no DNS configuration has no file. Useful for testing and for the setups where some agent
+// watches the directory and applies the configuration.
+func NewFileLinkConfigWriter(dir string) LinkConfigWriter {
+	return &fileLinkConfigWriter{
+		dir: dir,
+	}
+}
+
+func (w *fileLinkConfigWriter) path(ifName string) string {
+	return filepath.Join(w.dir, ifName+".conf")
+}
+
+func (w *fileLinkConfigWriter) Read(_ context.Context, ifName string) (*LinkConfig, error) {
+	b, err := os.ReadFile(w.path(ifName))
+	if os.IsNotExist(err) {
+		return new(LinkConfig), nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read DNS config of %s", ifName)
+	}
+
+	config := new(LinkConfig)
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case linkDNSKey:
+			config.DNSServers = append(config.DNSServers, strings.Fields(value)...)
+		case linkDomainsKey:
+			config.Domains = append(config.Domains, strings.Fields(value)...)
+		}
+	}
+	return config, nil
+}
+
+func (w *fileLinkConfigWriter) Write(_ context.Context, ifName string, config *LinkConfig) error {
+	if config.IsEmpty() {
+		if err := os.Remove(w.path(ifName)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to remove DNS config of %s", ifName)
+		}
+		return nil
+	}
+
+	var sb strings.Builder
+	_, _ = sb.WriteString(linkConfigSection + "\n")
+	if len(config.DNSServers) > 0 {
+		_, _ = sb.WriteString(linkDNSKey + "=" + strings.Join(config.DNSServers, " ") + "\n")
+	}
+	if len(config.Domains) > 0 {
+		_, _ = sb.WriteString(linkDomainsKey + "=" + strings.Join(config.Domains, " ") + "\n")
+	}
+
+	if err := os.MkdirAll(w.dir, os.ModePerm); err != nil {
+		return errors.Wrapf(err, "failed to create directory %s", w.dir)
+	}
+	if err := os.WriteFile(w.path(ifName), []byte(sb.String()), os.ModePerm); err != nil {
+		return errors.Wrapf(err, "failed to write DNS config of %s", ifName)
+	}
+	return nil
+}
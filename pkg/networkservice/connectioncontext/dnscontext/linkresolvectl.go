@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscontext
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type resolvectlLinkConfigWriter struct {
+	path string
+}
+
+// NewResolvectlLinkConfigWriter creates a LinkConfigWriter configuring systemd-resolved with the resolvectl binary
+// found at the path.
+func NewResolvectlLinkConfigWriter(path string) LinkConfigWriter {
+	return &resolvectlLinkConfigWriter{
+		path: path,
+	}
+}
+
+func (w *resolvectlLinkConfigWriter) run(ctx context.Context, args ...string) (string, error) {
+	// #nosec G204
+	out, err := exec.CommandContext(ctx, w.path, args...).CombinedOutput()
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to run %s %s: %s", w.path, strings.Join(args, " "), strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// Read parses the output of "resolvectl dns|domain <ifName>": Link 2 (eth0): 10.0.0.1 10.0.0.2
+func (w *resolvectlLinkConfigWriter) Read(ctx context.Context, ifName string) (*LinkConfig, error) {
+	config := new(LinkConfig)
+	for _, item := range []struct {
+		command string
+		values  *[]string
+	}{
+		{command: "dns", values: &config.DNSServers},
+		{command: "domain", values: &config.Domains},
+	} {
+		out, err := w.run(ctx, item.command, ifName)
+		if err != nil {
+			return nil, err
+		}
+		if _, values, ok := strings.Cut(out, "):"); ok {
+			*item.values = strings.Fields(values)
+		}
+	}
+	return config, nil
+}
+
+func (w *resolvectlLinkConfigWriter) Write(ctx context.Context, ifName string, config *LinkConfig) error {
+	if config.IsEmpty() {
+		_, err := w.run(ctx, "revert", ifName)
+		return err
+	}
+	if _, err := w.run(ctx, append([]string{"dns", ifName}, orReset(config.DNSServers)...)...); err != nil {
+		return err
+	}
+	if _, err := w.run(ctx, append([]string{"domain", ifName}, orReset(config.Domains)...)...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// orReset returns the values or an empty string argument, resolvectl resets the setting on it.
+func orReset(values []string) []string {
+	if len(values) == 0 {
+		return []string{""}
+	}
+	return values
+}
@@ -63,3 +63,10 @@ func WithChainContext(ctx context.Context) DNSOption {
 		c.chainContext = ctx
 	})
 }
+
+// WithLinkConfigWriter sets writer applying DNS configs per interface instead of rewriting resolv.conf.
+func WithLinkConfigWriter(w LinkConfigWriter) DNSOption {
+	return applyFunc(func(c *dnsContextClient) {
+		c.linkWriter = w
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mtu provides networkservice.NetworkService{Client,Server} chain elements lowering the connection context
+// MTU to the MTU negotiated for the selected mechanism, so the final MTU accounts for the tunnel overhead.
+package mtu
+
+import (
+	"strconv"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/capabilities"
+)
+
+// apply lowers conn.Context.MTU to the payload MTU negotiated for the selected mechanism
+func apply(conn *networkservice.Connection) {
+	mechanismMTU, err := strconv.ParseUint(conn.GetMechanism().GetParameters()[capabilities.PayloadMTU], 10, 32)
+	if err != nil || mechanismMTU == 0 {
+		return
+	}
+	if conn.GetContext() == nil {
+		conn.Context = &networkservice.ConnectionContext{}
+	}
+	if conn.GetContext().GetMTU() == 0 || uint32(mechanismMTU) < conn.GetContext().GetMTU() {
+		conn.GetContext().MTU = uint32(mechanismMTU)
+	}
+}
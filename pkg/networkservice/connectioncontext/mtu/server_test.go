@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtu_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/capabilities"
+	"github.com/ljkiraly/sdk/pkg/networkservice/connectioncontext/mtu"
+)
+
+func TestMTUServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	for _, testCase := range []struct {
+		name         string
+		contextMTU   uint32
+		mechanismMTU string
+		expected     uint32
+	}{
+		{name: "unset", contextMTU: 0, mechanismMTU: "1450", expected: 1450},
+		{name: "lowered", contextMTU: 1500, mechanismMTU: "1450", expected: 1450},
+		{name: "kept", contextMTU: 1400, mechanismMTU: "1450", expected: 1400},
+		{name: "no mechanism mtu", contextMTU: 1500, mechanismMTU: "", expected: 1500},
+	} {
+		request := &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Context: &networkservice.ConnectionContext{MTU: testCase.contextMTU},
+				Mechanism: &networkservice.Mechanism{
+					Cls:  cls.REMOTE,
+					Type: vxlan.MECHANISM,
+					Parameters: map[string]string{
+						capabilities.PayloadMTU: testCase.mechanismMTU,
+					},
+				},
+			},
+		}
+		conn, err := mtu.NewServer().Request(context.Background(), request)
+		require.NoError(t, err, testCase.name)
+		require.Equal(t, testCase.expected, conn.GetContext().GetMTU(), testCase.name)
+	}
+}
@@ -42,10 +42,12 @@ import (
 	"github.com/ljkiraly/sdk/pkg/registry/common/memory"
 	"github.com/ljkiraly/sdk/pkg/registry/common/setpayload"
 	"github.com/ljkiraly/sdk/pkg/registry/common/setregistrationtime"
+	registrytenant "github.com/ljkiraly/sdk/pkg/registry/common/tenant"
 	"github.com/ljkiraly/sdk/pkg/registry/core/chain"
 	"github.com/ljkiraly/sdk/pkg/registry/switchcase"
 	"github.com/ljkiraly/sdk/pkg/registry/utils/metadata"
 	"github.com/ljkiraly/sdk/pkg/tools/interdomain"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 	"github.com/ljkiraly/sdk/pkg/tools/token"
 )
 
@@ -57,6 +59,8 @@ type serverOptions struct {
 	defaultExpiration          time.Duration
 	proxyRegistryURL           *url.URL
 	dialOptions                []grpc.DialOption
+	tenantFunc                 tenant.Func
+	exportPolicy               tenant.ExportPolicy
 }
 
 // Option modifies server option value
@@ -123,6 +127,22 @@ func WithDialOptions(dialOptions ...grpc.DialOption) Option {
 	}
 }
 
+// WithTenantFunc sets function deriving the tenant from the caller SPIFFE ID. Entries are scoped by the tenant,
+// Find and watch results are filtered to the entries visible for the caller tenant. See the registry tenant package
+// for how the caller is resolved.
+func WithTenantFunc(tenantFunc tenant.Func) Option {
+	return func(o *serverOptions) {
+		o.tenantFunc = tenantFunc
+	}
+}
+
+// WithExportPolicy sets policy allowing tenants to see entries of other tenants
+func WithExportPolicy(exportPolicy tenant.ExportPolicy) Option {
+	return func(o *serverOptions) {
+		o.exportPolicy = exportPolicy
+	}
+}
+
 // NewServer creates new registry server based on memory storage
 func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options ...Option) registryserver.Registry {
 	opts := &serverOptions{
@@ -140,6 +160,7 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 	nseChain := chain.NewNetworkServiceEndpointRegistryServer(
 		grpcmetadata.NewNetworkServiceEndpointRegistryServer(),
 		updatepath.NewNetworkServiceEndpointRegistryServer(tokenGenerator),
+		registrytenant.NewNetworkServiceEndpointRegistryServer(opts.tenantFunc),
 		opts.authorizeNSERegistryServer,
 		begin.NewNetworkServiceEndpointRegistryServer(),
 		metadata.NewNetworkServiceEndpointServer(),
@@ -176,7 +197,7 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 				Action: chain.NewNetworkServiceEndpointRegistryServer(
 					setregistrationtime.NewNetworkServiceEndpointRegistryServer(),
 					expire.NewNetworkServiceEndpointRegistryServer(ctx, expire.WithDefaultExpiration(opts.defaultExpiration)),
-					memory.NewNetworkServiceEndpointRegistryServer(),
+					memory.NewNetworkServiceEndpointRegistryServer(memory.WithExportPolicy(opts.exportPolicy)),
 				),
 			},
 		),
@@ -184,6 +205,7 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 	nsChain := chain.NewNetworkServiceRegistryServer(
 		grpcmetadata.NewNetworkServiceRegistryServer(),
 		updatepath.NewNetworkServiceRegistryServer(tokenGenerator),
+		registrytenant.NewNetworkServiceRegistryServer(opts.tenantFunc),
 		opts.authorizeNSRegistryServer,
 		metadata.NewNetworkServiceServer(),
 		setpayload.NewNetworkServiceRegistryServer(),
@@ -210,7 +232,7 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 				Condition: func(c context.Context, ns *registry.NetworkService) bool {
 					return true
 				},
-				Action: memory.NewNetworkServiceRegistryServer(),
+				Action: memory.NewNetworkServiceRegistryServer(memory.WithExportPolicy(opts.exportPolicy)),
 			},
 		),
 	)
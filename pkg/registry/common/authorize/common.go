@@ -26,6 +26,8 @@ import (
 
 	"github.com/ljkiraly/sdk/pkg/registry/common/grpcmetadata"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 )
 
 // RegistryOpaInput represents input for policies in authorizNSEServer and authorizeNSServer
@@ -64,6 +66,20 @@ func (l *policiesList) check(ctx context.Context, input RegistryOpaInput) error
 	return nil
 }
 
+// getTenantRawMap returns the entries of the tenant from ctx by their names, the map keys are scoped by the tenant
+func getTenantRawMap(ctx context.Context, m *genericsync.Map[string, []string]) map[string][]string {
+	t := tenant.FromContext(ctx)
+	rawMap := make(map[string][]string)
+	m.Range(func(key string, value []string) bool {
+		if keyTenant, name := tenant.SplitScopedKey(key); keyTenant == t {
+			rawMap[name] = value
+		}
+		return true
+	})
+
+	return rawMap
+}
+
 func getRawMap(m *genericsync.Map[string, []string]) map[string][]string {
 	rawMap := make(map[string][]string)
 	m.Range(func(key string, value []string) bool {
@@ -119,3 +135,10 @@ func getLeftSideOfPath(path *grpcmetadata.Path) *grpcmetadata.Path {
 		PathSegments: path.PathSegments[:path.Index+1],
 	}
 }
+
+func withRevocationList(ctx context.Context, l *revocation.List) context.Context {
+	if l == nil {
+		return ctx
+	}
+	return revocation.WithList(ctx, l)
+}
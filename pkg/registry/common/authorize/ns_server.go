@@ -27,11 +27,14 @@ import (
 
 	"github.com/ljkiraly/sdk/pkg/registry/common/grpcmetadata"
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 )
 
 type authorizeNSServer struct {
-	policies     policiesList
-	nsPathIDsMap *genericsync.Map[string, []string]
+	policies       policiesList
+	nsPathIDsMap   *genericsync.Map[string, []string]
+	revocationList *revocation.List
 }
 
 // NewNetworkServiceRegistryServer - returns a new authorization registry.NetworkServiceRegistryServer
@@ -46,8 +49,9 @@ func NewNetworkServiceRegistryServer(opts ...Option) registry.NetworkServiceRegi
 	}
 
 	return &authorizeNSServer{
-		policies:     o.policies,
-		nsPathIDsMap: o.resourcePathIDsMap,
+		policies:       o.policies,
+		nsPathIDsMap:   o.resourcePathIDsMap,
+		revocationList: o.revocationList,
 	}
 }
 
@@ -60,7 +64,7 @@ func (s *authorizeNSServer) Register(ctx context.Context, ns *registry.NetworkSe
 	spiffeID := getSpiffeIDFromPath(ctx, path)
 	leftSide := getLeftSideOfPath(path)
 
-	rawMap := getRawMap(s.nsPathIDsMap)
+	rawMap := getTenantRawMap(ctx, s.nsPathIDsMap)
 	input := RegistryOpaInput{
 		ResourceID:         spiffeID.String(),
 		ResourceName:       ns.Name,
@@ -68,7 +72,7 @@ func (s *authorizeNSServer) Register(ctx context.Context, ns *registry.NetworkSe
 		PathSegments:       leftSide.PathSegments,
 		Index:              leftSide.Index,
 	}
-	if err := s.policies.check(ctx, input); err != nil {
+	if err := s.policies.check(withRevocationList(ctx, s.revocationList), input); err != nil {
 		return nil, err
 	}
 
@@ -76,7 +80,7 @@ func (s *authorizeNSServer) Register(ctx context.Context, ns *registry.NetworkSe
 	if err != nil {
 		return nil, err
 	}
-	s.nsPathIDsMap.Store(ns.Name, ns.PathIds)
+	s.nsPathIDsMap.Store(tenant.ScopedKey(tenant.FromContext(ctx), ns.Name), ns.PathIds)
 	return ns, nil
 }
 
@@ -93,7 +97,7 @@ func (s *authorizeNSServer) Unregister(ctx context.Context, ns *registry.Network
 	spiffeID := getSpiffeIDFromPath(ctx, path)
 	leftSide := getLeftSideOfPath(path)
 
-	rawMap := getRawMap(s.nsPathIDsMap)
+	rawMap := getTenantRawMap(ctx, s.nsPathIDsMap)
 	input := RegistryOpaInput{
 		ResourceID:         spiffeID.String(),
 		ResourceName:       ns.Name,
@@ -101,10 +105,10 @@ func (s *authorizeNSServer) Unregister(ctx context.Context, ns *registry.Network
 		PathSegments:       leftSide.PathSegments,
 		Index:              leftSide.Index,
 	}
-	if err := s.policies.check(ctx, input); err != nil {
+	if err := s.policies.check(withRevocationList(ctx, s.revocationList), input); err != nil {
 		return nil, err
 	}
 
-	s.nsPathIDsMap.Delete(ns.Name)
+	s.nsPathIDsMap.Delete(tenant.ScopedKey(tenant.FromContext(ctx), ns.Name))
 	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/registry/common/grpcmetadata"
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/nanoid"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 
 	"go.uber.org/goleak"
 )
@@ -148,3 +149,22 @@ func TestNetworkServiceRegistryAuthorize_ResourcePathIdMapHaveNoLeaks(t *testing
 	})
 	require.Equal(t, mapLen, 0)
 }
+
+func TestNetworkServiceRegistryAuthorization_Tenants(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := authorize.NewNetworkServiceRegistryServer(authorize.WithPolicies("etc/nsm/opa/registry/client_allowed.rego"))
+
+	ctx1 := tenant.WithTenant(grpcmetadata.PathWithContext(context.Background(), getPath(t, spiffeid1)), "tenant-1")
+	ctx2 := tenant.WithTenant(grpcmetadata.PathWithContext(context.Background(), getPath(t, spiffeid2)), "tenant-2")
+
+	_, err := server.Register(ctx1, &registry.NetworkService{Name: "ns", PathIds: []string{spiffeid1}})
+	require.NoError(t, err)
+	_, err = server.Register(ctx2, &registry.NetworkService{Name: "ns", PathIds: []string{spiffeid2}})
+	require.NoError(t, err)
+
+	_, err = server.Unregister(ctx1, &registry.NetworkService{Name: "ns", PathIds: []string{spiffeid1}})
+	require.NoError(t, err)
+	_, err = server.Unregister(ctx2, &registry.NetworkService{Name: "ns", PathIds: []string{spiffeid2}})
+	require.NoError(t, err)
+}
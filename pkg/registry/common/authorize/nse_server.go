@@ -27,11 +27,14 @@ import (
 
 	"github.com/ljkiraly/sdk/pkg/registry/common/grpcmetadata"
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 )
 
 type authorizeNSEServer struct {
-	policies      policiesList
-	nsePathIDsMap *genericsync.Map[string, []string]
+	policies       policiesList
+	nsePathIDsMap  *genericsync.Map[string, []string]
+	revocationList *revocation.List
 }
 
 // NewNetworkServiceEndpointRegistryServer - returns a new authorization registry.NetworkServiceEndpointRegistryServer
@@ -46,8 +49,9 @@ func NewNetworkServiceEndpointRegistryServer(opts ...Option) registry.NetworkSer
 	}
 
 	return &authorizeNSEServer{
-		policies:      o.policies,
-		nsePathIDsMap: o.resourcePathIDsMap,
+		policies:       o.policies,
+		nsePathIDsMap:  o.resourcePathIDsMap,
+		revocationList: o.revocationList,
 	}
 }
 
@@ -60,7 +64,7 @@ func (s *authorizeNSEServer) Register(ctx context.Context, nse *registry.Network
 	spiffeID := getSpiffeIDFromPath(ctx, path)
 	leftSide := getLeftSideOfPath(path)
 
-	rawMap := getRawMap(s.nsePathIDsMap)
+	rawMap := getTenantRawMap(ctx, s.nsePathIDsMap)
 	input := RegistryOpaInput{
 		ResourceID:         spiffeID.String(),
 		ResourceName:       nse.Name,
@@ -69,7 +73,7 @@ func (s *authorizeNSEServer) Register(ctx context.Context, nse *registry.Network
 		Index:              leftSide.Index,
 	}
 
-	if err := s.policies.check(ctx, input); err != nil {
+	if err := s.policies.check(withRevocationList(ctx, s.revocationList), input); err != nil {
 		return nil, err
 	}
 
@@ -77,7 +81,7 @@ func (s *authorizeNSEServer) Register(ctx context.Context, nse *registry.Network
 	if err != nil {
 		return nil, err
 	}
-	s.nsePathIDsMap.Store(nse.Name, nse.PathIds)
+	s.nsePathIDsMap.Store(tenant.ScopedKey(tenant.FromContext(ctx), nse.Name), nse.PathIds)
 	return nse, nil
 }
 
@@ -94,7 +98,7 @@ func (s *authorizeNSEServer) Unregister(ctx context.Context, nse *registry.Netwo
 	spiffeID := getSpiffeIDFromPath(ctx, path)
 	leftSide := getLeftSideOfPath(path)
 
-	rawMap := getRawMap(s.nsePathIDsMap)
+	rawMap := getTenantRawMap(ctx, s.nsePathIDsMap)
 	input := RegistryOpaInput{
 		ResourceID:         spiffeID.String(),
 		ResourceName:       nse.Name,
@@ -103,10 +107,10 @@ func (s *authorizeNSEServer) Unregister(ctx context.Context, nse *registry.Netwo
 		Index:              leftSide.Index,
 	}
 
-	if err := s.policies.check(ctx, input); err != nil {
+	if err := s.policies.check(withRevocationList(ctx, s.revocationList), input); err != nil {
 		return nil, err
 	}
 
-	s.nsePathIDsMap.Delete(nse.Name)
+	s.nsePathIDsMap.Delete(tenant.ScopedKey(tenant.FromContext(ctx), nse.Name))
 	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
 }
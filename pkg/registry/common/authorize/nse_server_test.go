@@ -32,6 +32,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/registry/common/grpcmetadata"
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/nanoid"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 
 	"go.uber.org/goleak"
 )
@@ -70,6 +71,37 @@ func TestNetworkServiceEndpointRegistryAuthorization(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNetworkServiceEndpointRegistryAuthorization_Tenants(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := authorize.NewNetworkServiceEndpointRegistryServer(authorize.WithPolicies("etc/nsm/opa/registry/client_allowed.rego"))
+
+	ctx1 := tenant.WithTenant(grpcmetadata.PathWithContext(context.Background(), getPath(t, spiffeid1)), "tenant-1")
+	ctx2 := tenant.WithTenant(grpcmetadata.PathWithContext(context.Background(), getPath(t, spiffeid2)), "tenant-2")
+
+	// The same name registered by different tenants doesn't collide
+	_, err := server.Register(ctx1, &registry.NetworkServiceEndpoint{Name: "nse", PathIds: []string{spiffeid1}})
+	require.NoError(t, err)
+	_, err = server.Register(ctx2, &registry.NetworkServiceEndpoint{Name: "nse", PathIds: []string{spiffeid2}})
+	require.NoError(t, err)
+
+	// Path IDs of one tenant are not overwritten by another one
+	_, err = server.Register(ctx1, &registry.NetworkServiceEndpoint{Name: "nse", PathIds: []string{spiffeid1}})
+	require.NoError(t, err)
+
+	_, err = server.Unregister(ctx2, &registry.NetworkServiceEndpoint{Name: "nse", PathIds: []string{spiffeid2}})
+	require.NoError(t, err)
+	_, err = server.Unregister(ctx1, &registry.NetworkServiceEndpoint{Name: "nse", PathIds: []string{spiffeid1}})
+	require.NoError(t, err)
+
+	// The same tenant still can't take over the name
+	_, err = server.Register(ctx1, &registry.NetworkServiceEndpoint{Name: "nse", PathIds: []string{spiffeid1}})
+	require.NoError(t, err)
+	_, err = server.Register(tenant.WithTenant(grpcmetadata.PathWithContext(context.Background(), getPath(t, spiffeid2)), "tenant-1"),
+		&registry.NetworkServiceEndpoint{Name: "nse", PathIds: []string{spiffeid2}})
+	require.Error(t, err)
+}
+
 type randomErrorNSEServer struct {
 	errorChance float32
 }
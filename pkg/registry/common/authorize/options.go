@@ -21,11 +21,13 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ljkiraly/sdk/pkg/tools/opa"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
 )
 
 type options struct {
 	policies           policiesList
 	resourcePathIDsMap *genericsync.Map[string, []string]
+	revocationList     *revocation.List
 }
 
 // Option is authorization option for server
@@ -59,3 +61,10 @@ func WithResourcePathIDsMap(m *genericsync.Map[string, []string]) Option {
 		o.resourcePathIDsMap = m
 	}
 }
+
+// WithRevocationList sets revocation list to be passed to the policies input on Register and Unregister
+func WithRevocationList(l *revocation.List) Option {
+	return func(o *options) {
+		o.revocationList = l
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/registry/common/grpcmetadata"
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 )
 
 type beginNSClient struct {
@@ -37,10 +38,10 @@ type beginNSClient struct {
 }
 
 func (b *beginNSClient) Register(ctx context.Context, in *registry.NetworkService, opts ...grpc.CallOption) (*registry.NetworkService, error) {
-	id := in.GetName()
-	if id == "" {
+	if in.GetName() == "" {
 		return nil, errors.New("registry.NetworkService.Name must not be zero valued")
 	}
+	id := tenant.ScopedKey(tenant.FromContext(ctx), in.GetName())
 	// If some other EventFactory is already in the ctx... we are already running in an executor, and can just execute normally
 	if fromContext(ctx) != nil {
 		return next.NetworkServiceRegistryClient(ctx).Register(ctx, in, opts...)
@@ -89,7 +90,7 @@ func (b *beginNSClient) Find(ctx context.Context, in *registry.NetworkServiceQue
 }
 
 func (b *beginNSClient) Unregister(ctx context.Context, in *registry.NetworkService, opts ...grpc.CallOption) (*empty.Empty, error) {
-	id := in.GetName()
+	id := tenant.ScopedKey(tenant.FromContext(ctx), in.GetName())
 	if fromContext(ctx) != nil {
 		return next.NetworkServiceRegistryClient(ctx).Unregister(ctx, in, opts...)
 	}
@@ -28,6 +28,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/registry/common/grpcmetadata"
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 )
 
 type beginNSServer struct {
@@ -35,10 +36,10 @@ type beginNSServer struct {
 }
 
 func (b *beginNSServer) Register(ctx context.Context, in *registry.NetworkService) (*registry.NetworkService, error) {
-	id := in.GetName()
-	if id == "" {
+	if in.GetName() == "" {
 		return nil, errors.New("NetworkService.Name can not be zero valued")
 	}
+	id := tenant.ScopedKey(tenant.FromContext(ctx), in.GetName())
 	// If some other EventFactory is already in the ctx... we are already running in an executor, and can just execute normally
 	if fromContext(ctx) != nil {
 		return next.NetworkServiceRegistryServer(ctx).Register(ctx, in)
@@ -85,7 +86,7 @@ func (b *beginNSServer) Find(query *registry.NetworkServiceQuery, server registr
 }
 
 func (b *beginNSServer) Unregister(ctx context.Context, in *registry.NetworkService) (*empty.Empty, error) {
-	id := in.GetName()
+	id := tenant.ScopedKey(tenant.FromContext(ctx), in.GetName())
 	// 	// If some other EventFactory is already in the ctx... we are already running in an executor, and can just execute normally
 	if fromContext(ctx) != nil {
 		return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, in)
@@ -29,6 +29,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/registry/common/grpcmetadata"
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 )
 
 type beginNSEClient struct {
@@ -36,10 +37,10 @@ type beginNSEClient struct {
 }
 
 func (b *beginNSEClient) Register(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
-	id := in.GetName()
-	if id == "" {
+	if in.GetName() == "" {
 		return nil, errors.New("registry.NetworkServiceEndpoint.Name must not be zero valued")
 	}
+	id := tenant.ScopedKey(tenant.FromContext(ctx), in.GetName())
 	// If some other EventFactory is already in the ctx... we are already running in an executor, and can just execute normally
 	if fromContext(ctx) != nil {
 		return next.NetworkServiceEndpointRegistryClient(ctx).Register(ctx, in, opts...)
@@ -82,7 +83,7 @@ func (b *beginNSEClient) Find(ctx context.Context, in *registry.NetworkServiceEn
 }
 
 func (b *beginNSEClient) Unregister(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*empty.Empty, error) {
-	id := in.GetName()
+	id := tenant.ScopedKey(tenant.FromContext(ctx), in.GetName())
 	if fromContext(ctx) != nil {
 		return next.NetworkServiceEndpointRegistryClient(ctx).Unregister(ctx, in, opts...)
 	}
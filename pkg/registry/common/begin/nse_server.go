@@ -27,6 +27,7 @@ import (
 
 	"github.com/ljkiraly/sdk/pkg/registry/common/grpcmetadata"
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 )
 
 type beginNSEServer struct {
@@ -34,10 +35,10 @@ type beginNSEServer struct {
 }
 
 func (b *beginNSEServer) Register(ctx context.Context, in *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
-	id := in.GetName()
-	if id == "" {
+	if in.GetName() == "" {
 		return nil, errors.New("NetworkServiceEndpoint.Name can not be zero valued")
 	}
+	id := tenant.ScopedKey(tenant.FromContext(ctx), in.GetName())
 	// If some other EventFactory is already in the ctx... we are already running in an executor, and can just execute normally
 	if fromContext(ctx) != nil {
 		return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, in)
@@ -76,7 +77,7 @@ func (b *beginNSEServer) Find(query *registry.NetworkServiceEndpointQuery, serve
 }
 
 func (b *beginNSEServer) Unregister(ctx context.Context, in *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
-	id := in.GetName()
+	id := tenant.ScopedKey(tenant.FromContext(ctx), in.GetName())
 	// 	// If some other EventFactory is already in the ctx... we are already running in an executor, and can just execute normally
 	if fromContext(ctx) != nil {
 		return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, in)
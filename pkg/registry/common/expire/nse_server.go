@@ -33,6 +33,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/clock"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 	"github.com/ljkiraly/sdk/pkg/tools/token"
 )
 
@@ -88,11 +89,12 @@ func (s *expireNSEServer) Register(ctx context.Context, nse *registry.NetworkSer
 		logger.Infof("selected expiration time %v for %v", expirationTime, resp.GetName())
 	}
 
+	key := tenant.ScopedKey(tenant.FromContext(ctx), nse.GetName())
 	expireContext, cancel := context.WithCancel(s.ctx)
-	if v, ok := s.Map.LoadAndDelete(nse.GetName()); ok {
+	if v, ok := s.Map.LoadAndDelete(key); ok {
 		v()
 	}
-	s.Map.Store(nse.GetName(), cancel)
+	s.Map.Store(key, cancel)
 
 	expireCh := timeClock.After(timeClock.Until(expirationTime.Local()) - requestTimeout)
 
@@ -113,7 +115,7 @@ func (s *expireNSEServer) Find(query *registry.NetworkServiceEndpointQuery, serv
 }
 
 func (s *expireNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
-	if oldCancel, loaded := s.LoadAndDelete(nse.Name); loaded {
+	if oldCancel, loaded := s.LoadAndDelete(tenant.ScopedKey(tenant.FromContext(ctx), nse.GetName())); loaded {
 		oldCancel()
 	}
 	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
@@ -47,6 +47,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/registry/utils/inject/injectpeertoken"
 	"github.com/ljkiraly/sdk/pkg/tools/clock"
 	"github.com/ljkiraly/sdk/pkg/tools/clockmock"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 	"github.com/ljkiraly/sdk/pkg/tools/token"
 )
 
@@ -116,6 +117,41 @@ func TestExpireNSEServer_ShouldCorrectlySetExpirationTime_InRemoteCase(t *testin
 	require.Equal(t, expireTimeout, clockMock.Until(resp.ExpirationTime.AsTime().Local()))
 }
 
+func TestExpireNSEServer_TenantsWithTheSameName(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		injectpeertoken.NewNetworkServiceEndpointRegistryServer(generateTestToken(ctx, expireTimeout)),
+		updatepath.NewNetworkServiceEndpointRegistryServer(generateTestToken(ctx, expireTimeout)),
+		begin.NewNetworkServiceEndpointRegistryServer(),
+		expire.NewNetworkServiceEndpointRegistryServer(ctx),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+	c := adapters.NetworkServiceEndpointServerToClient(s)
+
+	tenantCtxs := []context.Context{tenant.WithTenant(ctx, "tenant-a"), tenant.WithTenant(ctx, "tenant-b")}
+	for _, tenantCtx := range tenantCtxs {
+		_, err := s.Register(tenantCtx, &registry.NetworkServiceEndpoint{Name: nseName})
+		require.NoError(t, err)
+		clockMock.Add(expireTimeout / 2)
+	}
+
+	// Registration of tenant-b doesn't cancel the expiration of tenant-a
+	for _, tenantCtx := range tenantCtxs {
+		require.Eventually(t, func() bool {
+			clockMock.Add(expireTimeout / 10)
+			nses, err := find(tenantCtx, c)
+			return err == nil && len(nses) == 0
+		}, time.Second, testTick)
+	}
+}
+
 func TestExpireNSEServer_ShouldUseLessExpirationTimeFromInput_AndWork(t *testing.T) {
 	t.Cleanup(func() { goleak.VerifyNone(t) })
 
@@ -16,4 +16,13 @@
 
 package memory
 
+import (
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
+)
+
 const defaultEventChannelSize = 10
+
+// scopedKey returns storage key of the entry with name registered by owner tenant
+func scopedKey(owner, name string) string {
+	return tenant.ScopedKey(owner, name)
+}
@@ -32,6 +32,7 @@ import (
 
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/matchutils"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 )
 
 type memoryNSServer struct {
@@ -39,6 +40,9 @@ type memoryNSServer struct {
 	executor         serialize.Executor
 	eventChannels    map[string]chan *registry.NetworkServiceResponse
 	eventChannelSize int
+	tenants          genericsync.Map[string, string]
+	eventTenants     map[string]string
+	exportPolicy     tenant.ExportPolicy
 }
 
 // NewNetworkServiceRegistryServer creates new memory based NetworkServiceRegistryServer
@@ -46,6 +50,7 @@ func NewNetworkServiceRegistryServer(options ...Option) registry.NetworkServiceR
 	s := &memoryNSServer{
 		eventChannelSize: defaultEventChannelSize,
 		eventChannels:    make(map[string]chan *registry.NetworkServiceResponse),
+		eventTenants:     make(map[string]string),
 	}
 	for _, o := range options {
 		o.apply(s)
@@ -57,31 +62,41 @@ func (s *memoryNSServer) setEventChannelSize(l int) {
 	s.eventChannelSize = l
 }
 
+func (s *memoryNSServer) setExportPolicy(policy tenant.ExportPolicy) {
+	s.exportPolicy = policy
+}
+
 func (s *memoryNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
 	r, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
 	if err != nil {
 		return nil, err
 	}
 
-	s.networkServices.Store(r.Name, r.Clone())
+	owner := tenant.FromContext(ctx)
+	key := scopedKey(owner, r.Name)
+	s.networkServices.Store(key, r.Clone())
+	s.tenants.Store(key, owner)
 
-	s.sendEvent(&registry.NetworkServiceResponse{NetworkService: r})
+	s.sendEvent(owner, &registry.NetworkServiceResponse{NetworkService: r})
 
 	return r, nil
 }
 
-func (s *memoryNSServer) sendEvent(event *registry.NetworkServiceResponse) {
+func (s *memoryNSServer) sendEvent(owner string, event *registry.NetworkServiceResponse) {
 	event = event.Clone()
 	s.executor.AsyncExec(func() {
-		for _, ch := range s.eventChannels {
-			ch <- event.Clone()
+		for id, ch := range s.eventChannels {
+			if tenant.IsVisible(s.exportPolicy, owner, s.eventTenants[id], event.GetNetworkService().GetName()) {
+				ch <- event.Clone()
+			}
 		}
 	})
 }
 
 func (s *memoryNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	consumer := tenant.FromContext(server.Context())
 	if !query.Watch {
-		for _, ns := range s.allMatches(query) {
+		for _, ns := range s.allMatches(consumer, query) {
 			nsResp := &registry.NetworkServiceResponse{
 				NetworkService: ns,
 			}
@@ -98,7 +113,8 @@ func (s *memoryNSServer) Find(query *registry.NetworkServiceQuery, server regist
 
 	s.executor.AsyncExec(func() {
 		s.eventChannels[id] = eventCh
-		for _, entity := range s.allMatches(query) {
+		s.eventTenants[id] = consumer
+		for _, entity := range s.allMatches(consumer, query) {
 			eventCh <- &registry.NetworkServiceResponse{NetworkService: entity}
 		}
 	})
@@ -113,8 +129,11 @@ func (s *memoryNSServer) Find(query *registry.NetworkServiceQuery, server regist
 	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
 }
 
-func (s *memoryNSServer) allMatches(query *registry.NetworkServiceQuery) (matches []*registry.NetworkService) {
-	s.networkServices.Range(func(_ string, ns *registry.NetworkService) bool {
+func (s *memoryNSServer) allMatches(consumer string, query *registry.NetworkServiceQuery) (matches []*registry.NetworkService) {
+	s.networkServices.Range(func(key string, ns *registry.NetworkService) bool {
+		if owner, _ := s.tenants.Load(key); !tenant.IsVisible(s.exportPolicy, owner, consumer, ns.GetName()) {
+			return true
+		}
 		if matchutils.MatchNetworkServices(query.NetworkService, ns) {
 			matches = append(matches, ns.Clone())
 		}
@@ -128,6 +147,7 @@ func (s *memoryNSServer) closeEventChannel(id string, eventCh <-chan *registry.N
 
 	s.executor.AsyncExec(func() {
 		delete(s.eventChannels, id)
+		delete(s.eventTenants, id)
 		cancel()
 	})
 
@@ -162,9 +182,12 @@ func (s *memoryNSServer) receiveEvent(
 }
 
 func (s *memoryNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
-	if unregisterNS, ok := s.networkServices.LoadAndDelete(ns.GetName()); ok {
+	owner := tenant.FromContext(ctx)
+	key := scopedKey(owner, ns.GetName())
+	if unregisterNS, ok := s.networkServices.LoadAndDelete(key); ok {
+		s.tenants.Delete(key)
 		unregisterNS = unregisterNS.Clone()
-		s.sendEvent(&registry.NetworkServiceResponse{NetworkService: unregisterNS, Deleted: true})
+		s.sendEvent(owner, &registry.NetworkServiceResponse{NetworkService: unregisterNS, Deleted: true})
 	}
 	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
 }
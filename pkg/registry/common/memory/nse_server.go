@@ -32,6 +32,7 @@ import (
 
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/matchutils"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 )
 
 type memoryNSEServer struct {
@@ -39,6 +40,9 @@ type memoryNSEServer struct {
 	executor                serialize.Executor
 	eventChannels           map[string]chan *registry.NetworkServiceEndpointResponse
 	eventChannelSize        int
+	tenants                 genericsync.Map[string, string]
+	eventTenants            map[string]string
+	exportPolicy            tenant.ExportPolicy
 }
 
 // NewNetworkServiceEndpointRegistryServer creates new memory based NetworkServiceEndpointRegistryServer
@@ -46,6 +50,7 @@ func NewNetworkServiceEndpointRegistryServer(options ...Option) registry.Network
 	s := &memoryNSEServer{
 		eventChannelSize: defaultEventChannelSize,
 		eventChannels:    make(map[string]chan *registry.NetworkServiceEndpointResponse),
+		eventTenants:     make(map[string]string),
 	}
 	for _, o := range options {
 		o.apply(s)
@@ -57,31 +62,41 @@ func (s *memoryNSEServer) setEventChannelSize(l int) {
 	s.eventChannelSize = l
 }
 
+func (s *memoryNSEServer) setExportPolicy(policy tenant.ExportPolicy) {
+	s.exportPolicy = policy
+}
+
 func (s *memoryNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
 	r, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
 	if err != nil {
 		return nil, err
 	}
 
-	s.networkServiceEndpoints.Store(r.Name, r.Clone())
+	owner := tenant.FromContext(ctx)
+	key := scopedKey(owner, r.Name)
+	s.networkServiceEndpoints.Store(key, r.Clone())
+	s.tenants.Store(key, owner)
 
-	s.sendEvent(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: r})
+	s.sendEvent(owner, &registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: r})
 
 	return r, nil
 }
 
-func (s *memoryNSEServer) sendEvent(event *registry.NetworkServiceEndpointResponse) {
+func (s *memoryNSEServer) sendEvent(owner string, event *registry.NetworkServiceEndpointResponse) {
 	event = event.Clone()
 	s.executor.AsyncExec(func() {
-		for _, ch := range s.eventChannels {
-			ch <- event.Clone()
+		for id, ch := range s.eventChannels {
+			if tenant.IsVisible(s.exportPolicy, owner, s.eventTenants[id], event.GetNetworkServiceEndpoint().GetName()) {
+				ch <- event.Clone()
+			}
 		}
 	})
 }
 
 func (s *memoryNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	consumer := tenant.FromContext(server.Context())
 	if !query.Watch {
-		for _, nse := range s.allMatches(query) {
+		for _, nse := range s.allMatches(consumer, query) {
 			nseResp := &registry.NetworkServiceEndpointResponse{
 				NetworkServiceEndpoint: nse,
 			}
@@ -101,7 +116,8 @@ func (s *memoryNSEServer) Find(query *registry.NetworkServiceEndpointQuery, serv
 
 	s.executor.AsyncExec(func() {
 		s.eventChannels[id] = eventCh
-		for _, entity := range s.allMatches(query) {
+		s.eventTenants[id] = consumer
+		for _, entity := range s.allMatches(consumer, query) {
 			eventCh <- &registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: entity}
 		}
 	})
@@ -116,8 +132,11 @@ func (s *memoryNSEServer) Find(query *registry.NetworkServiceEndpointQuery, serv
 	return nil
 }
 
-func (s *memoryNSEServer) allMatches(query *registry.NetworkServiceEndpointQuery) (matches []*registry.NetworkServiceEndpoint) {
-	s.networkServiceEndpoints.Range(func(_ string, nse *registry.NetworkServiceEndpoint) bool {
+func (s *memoryNSEServer) allMatches(consumer string, query *registry.NetworkServiceEndpointQuery) (matches []*registry.NetworkServiceEndpoint) {
+	s.networkServiceEndpoints.Range(func(key string, nse *registry.NetworkServiceEndpoint) bool {
+		if owner, _ := s.tenants.Load(key); !tenant.IsVisible(s.exportPolicy, owner, consumer, nse.GetName()) {
+			return true
+		}
 		if matchutils.MatchNetworkServiceEndpoints(query.NetworkServiceEndpoint, nse) {
 			matches = append(matches, nse.Clone())
 		}
@@ -131,6 +150,7 @@ func (s *memoryNSEServer) closeEventChannel(id string, eventCh <-chan *registry.
 
 	s.executor.AsyncExec(func() {
 		delete(s.eventChannels, id)
+		delete(s.eventTenants, id)
 		cancel()
 	})
 
@@ -165,9 +185,12 @@ func (s *memoryNSEServer) receiveEvent(
 }
 
 func (s *memoryNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
-	if unregisterNSE, ok := s.networkServiceEndpoints.LoadAndDelete(nse.GetName()); ok {
+	owner := tenant.FromContext(ctx)
+	key := scopedKey(owner, nse.GetName())
+	if unregisterNSE, ok := s.networkServiceEndpoints.LoadAndDelete(key); ok {
+		s.tenants.Delete(key)
 		unregisterNSE = unregisterNSE.Clone()
-		s.sendEvent(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: unregisterNSE, Deleted: true})
+		s.sendEvent(owner, &registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: unregisterNSE, Deleted: true})
 	}
 	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
 }
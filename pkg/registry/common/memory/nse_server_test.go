@@ -35,6 +35,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/registry/common/memory"
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
 	"github.com/ljkiraly/sdk/pkg/registry/core/streamchannel"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
 )
 
 func TestNetworkServiceEndpointRegistryServer_RegisterAndFind(t *testing.T) {
@@ -376,6 +377,81 @@ func TestNetworkServiceEndpointRegistryServer_ShouldReceiveAllUnregisters(t *tes
 	<-ctx.Done()
 }
 
+func TestNetworkServiceEndpointRegistryServer_Tenants(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	s := next.NewNetworkServiceEndpointRegistryServer(memory.NewNetworkServiceEndpointRegistryServer(
+		memory.WithExportPolicy(tenant.NewExportPolicy(&tenant.Export{
+			Owner:     "tenant-a",
+			Name:      "exported-.*",
+			Consumers: []string{"tenant-b"},
+		})),
+	))
+
+	tenantA := tenant.WithTenant(context.Background(), "tenant-a")
+	tenantB := tenant.WithTenant(context.Background(), "tenant-b")
+
+	for _, name := range []string{"nse", "exported-nse"} {
+		_, err := s.Register(tenantA, &registry.NetworkServiceEndpoint{Name: name})
+		require.NoError(t, err)
+	}
+	_, err := s.Register(tenantB, &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.NoError(t, err)
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "shared-nse"})
+	require.NoError(t, err)
+
+	find := func(ctx context.Context) (names []string) {
+		ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+		err := s.Find(&registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+		}, streamchannel.NewNetworkServiceEndpointFindServer(ctx, ch))
+		require.NoError(t, err)
+		close(ch)
+		for resp := range ch {
+			names = append(names, resp.GetNetworkServiceEndpoint().GetName())
+		}
+		return names
+	}
+
+	require.ElementsMatch(t, []string{"nse", "exported-nse", "shared-nse"}, find(tenantA))
+	require.ElementsMatch(t, []string{"nse", "exported-nse", "shared-nse"}, find(tenantB))
+	require.ElementsMatch(t, []string{"shared-nse"}, find(tenant.WithTenant(context.Background(), "tenant-c")))
+
+	_, err = s.Unregister(tenantB, &registry.NetworkServiceEndpoint{Name: "exported-nse"})
+	require.NoError(t, err)
+	_, err = s.Unregister(tenantB, &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"nse", "exported-nse", "shared-nse"}, find(tenantA))
+	require.ElementsMatch(t, []string{"exported-nse", "shared-nse"}, find(tenantB))
+}
+
+func TestNetworkServiceEndpointRegistryServer_TenantsWatch(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	s := next.NewNetworkServiceEndpointRegistryServer(memory.NewNetworkServiceEndpointRegistryServer())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	go func() {
+		_ = s.Find(&registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+			Watch:                  true,
+		}, streamchannel.NewNetworkServiceEndpointFindServer(tenant.WithTenant(ctx, "tenant-a"), ch))
+	}()
+
+	_, err := s.Register(tenant.WithTenant(ctx, "tenant-b"), &registry.NetworkServiceEndpoint{Name: "nse-b"})
+	require.NoError(t, err)
+	_, err = s.Register(tenant.WithTenant(ctx, "tenant-a"), &registry.NetworkServiceEndpoint{Name: "nse-a"})
+	require.NoError(t, err)
+
+	nse, err := receiveNSER(ctx, ch)
+	require.NoError(t, err)
+	require.Equal(t, "nse-a", nse.GetNetworkServiceEndpoint().GetName())
+	require.Never(t, func() bool { return len(ch) > 0 }, time.Millisecond*100, time.Millisecond*10)
+}
+
 func createLabeledNSE1() *registry.NetworkServiceEndpoint {
 	labels := map[string]*registry.NetworkServiceLabels{
 		"Service1": {
@@ -16,8 +16,11 @@
 
 package memory
 
+import "github.com/ljkiraly/sdk/pkg/tools/tenant"
+
 type configurable interface {
 	setEventChannelSize(int)
+	setExportPolicy(tenant.ExportPolicy)
 }
 
 // Option is memory registry configuration option
@@ -37,3 +40,11 @@ func WithEventChannelSize(l int) Option {
 		c.setEventChannelSize(l)
 	})
 }
+
+// WithExportPolicy sets policy allowing tenants to see entries of other tenants. By default, entries are visible only
+// for the tenant registered them and entries registered without tenant are visible for everyone.
+func WithExportPolicy(policy tenant.ExportPolicy) Option {
+	return applierFunc(func(c configurable) {
+		c.setExportPolicy(policy)
+	})
+}
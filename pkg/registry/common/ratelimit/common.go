@@ -0,0 +1,33 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides registry chain elements limiting the rate and the concurrency of the incoming Registers
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/ratelimit"
+	"github.com/ljkiraly/sdk/pkg/tools/spire"
+)
+
+func acquire(ctx context.Context, limiter *ratelimit.Limiter, keys map[ratelimit.KeyType]string) (func(), error) {
+	if spiffeID, err := spire.PeerSpiffeIDFromContext(ctx); err == nil {
+		keys[ratelimit.SpiffeID] = spiffeID.String()
+	}
+	return limiter.Acquire(clock.FromContext(ctx).Now(), keys)
+}
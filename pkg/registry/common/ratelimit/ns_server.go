@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/registry/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/ratelimit"
+)
+
+type rateLimitNSServer struct {
+	limiter *ratelimit.Limiter
+}
+
+// NewNetworkServiceRegistryServer returns a new rate limiting registry.NetworkServiceRegistryServer.
+// Registers are keyed by the peer SPIFFE ID and the network service name.
+// Throttled Registers are rejected with ResourceExhausted error.
+func NewNetworkServiceRegistryServer(limiter *ratelimit.Limiter) registry.NetworkServiceRegistryServer {
+	return &rateLimitNSServer{
+		limiter: limiter,
+	}
+}
+
+func (s *rateLimitNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	release, err := acquire(ctx, s.limiter, map[ratelimit.KeyType]string{
+		ratelimit.NetworkService: ns.GetName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *rateLimitNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *rateLimitNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
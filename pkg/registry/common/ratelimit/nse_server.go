@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/registry/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/ratelimit"
+)
+
+type rateLimitNSEServer struct {
+	limiter *ratelimit.Limiter
+}
+
+// NewNetworkServiceEndpointRegistryServer returns a new rate limiting registry.NetworkServiceEndpointRegistryServer.
+// Registers are keyed by the peer SPIFFE ID and the NSE name.
+// Throttled Registers are rejected with ResourceExhausted error.
+func NewNetworkServiceEndpointRegistryServer(limiter *ratelimit.Limiter) registry.NetworkServiceEndpointRegistryServer {
+	return &rateLimitNSEServer{
+		limiter: limiter,
+	}
+}
+
+func (s *rateLimitNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	release, err := acquire(ctx, s.limiter, map[ratelimit.KeyType]string{
+		ratelimit.NetworkServiceEndpoint: nse.GetName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *rateLimitNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *rateLimitNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ljkiraly/sdk/pkg/registry/common/ratelimit"
+	ratelimittools "github.com/ljkiraly/sdk/pkg/tools/ratelimit"
+)
+
+func TestRateLimitNSEServer(t *testing.T) {
+	limiter, err := ratelimittools.NewLimiter(&ratelimittools.Config{
+		Rules: []*ratelimittools.Rule{
+			{Key: ratelimittools.NetworkServiceEndpoint, Rate: 1, Burst: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	server := ratelimit.NewNetworkServiceEndpointRegistryServer(limiter)
+
+	for i := 0; i < 2; i++ {
+		_, err = server.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+		require.NoError(t, err)
+	}
+	_, err = server.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = server.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.NoError(t, err)
+
+	_, err = server.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+}
+
+func TestRateLimitNSServer(t *testing.T) {
+	limiter, err := ratelimittools.NewLimiter(&ratelimittools.Config{
+		Rules: []*ratelimittools.Rule{
+			{Key: ratelimittools.NetworkService, Pattern: "ns-1", Rate: 1, Burst: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	server := ratelimit.NewNetworkServiceRegistryServer(limiter)
+
+	_, err = server.Register(context.Background(), &registry.NetworkService{Name: "ns-1"})
+	require.NoError(t, err)
+	_, err = server.Register(context.Background(), &registry.NetworkService{Name: "ns-1"})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = server.Register(context.Background(), &registry.NetworkService{Name: "ns-2"})
+	require.NoError(t, err)
+}
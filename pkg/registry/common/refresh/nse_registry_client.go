@@ -28,19 +28,27 @@ import (
 	"github.com/ljkiraly/sdk/pkg/registry/common/begin"
 	"github.com/ljkiraly/sdk/pkg/registry/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/refreshutils"
 )
 
+const lagKind = "nse"
+
 type refreshNSEClient struct {
 	ctx context.Context
+	options
 	genericsync.Map[string, context.CancelFunc]
 }
 
 // NewNetworkServiceEndpointRegistryClient creates new NetworkServiceEndpointRegistryClient that will refresh expiration
 // time for registered NSEs
-func NewNetworkServiceEndpointRegistryClient(ctx context.Context) registry.NetworkServiceEndpointRegistryClient {
-	return &refreshNSEClient{
+func NewNetworkServiceEndpointRegistryClient(ctx context.Context, opts ...Option) registry.NetworkServiceEndpointRegistryClient {
+	c := &refreshNSEClient{
 		ctx: ctx,
 	}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	return c
 }
 
 func (c *refreshNSEClient) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
@@ -63,13 +71,21 @@ func (c *refreshNSEClient) Register(ctx context.Context, nse *registry.NetworkSe
 	var clockTime = clock.FromContext(ctx)
 
 	if resp.GetExpirationTime() != nil {
-		var refreshCh = clockTime.After(2 * clockTime.Until(resp.GetExpirationTime().AsTime().Local()) / 3)
+		var expireAfter = clockTime.Until(resp.GetExpirationTime().AsTime().Local())
+		var refreshAfter = refreshutils.Jitter(2*expireAfter/3, c.jitter)
+		var scheduled = clockTime.Now().Add(refreshAfter)
+		var deadline = scheduled.Add((expireAfter - refreshAfter) / 2)
+		var refreshCh = clockTime.After(refreshAfter)
 
 		go func() {
 			select {
 			case <-refreshCtx.Done():
 				return
 			case <-refreshCh:
+				if _, err := c.limiter.Wait(clock.WithClock(refreshCtx, clockTime), deadline); err != nil {
+					return
+				}
+				refreshutils.RecordLag(ctx, lagKind, clockTime.Since(scheduled))
 				<-factory.Register(begin.CancelContext(refreshCtx))
 			}
 		}()
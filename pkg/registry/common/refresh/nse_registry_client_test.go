@@ -39,6 +39,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/tools/clock"
 	"github.com/ljkiraly/sdk/pkg/tools/clockmock"
 	"github.com/ljkiraly/sdk/pkg/tools/interdomain"
+	"github.com/ljkiraly/sdk/pkg/tools/refreshutils"
 )
 
 const (
@@ -300,6 +301,41 @@ func Test_RefreshNSEClient_CorrectInitialRegTime(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNetworkServiceEndpointRefreshClient_Limiter(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	countClient := new(requestCountClient)
+	client := next.NewNetworkServiceEndpointRegistryClient(
+		begin.NewNetworkServiceEndpointRegistryClient(),
+		refresh.NewNetworkServiceEndpointRegistryClient(ctx, refresh.WithLimiter(refreshutils.NewLimiter(1))),
+		countClient,
+	)
+
+	for _, name := range []string{"nse-1", "nse-2"} {
+		nse := testNSE(clockMock)
+		nse.Name = name
+		_, err := client.Register(ctx, nse)
+		require.NoError(t, err)
+	}
+
+	// Both re-registrations are scheduled at the same time, the limiter lets only one of them through
+	clockMock.Add(2 * expireTimeout / 3)
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&countClient.requestCount) == 3 }, testWait, testTick)
+	require.Never(t, func() bool { return atomic.LoadInt32(&countClient.requestCount) > 3 }, testWait, testTick)
+
+	// The second one is delayed to the next slot
+	require.Eventually(t, func() bool {
+		clockMock.Add(time.Second / 10)
+		return atomic.LoadInt32(&countClient.requestCount) == 4
+	}, testWait*10, testTick)
+}
+
 type requestCountClient struct {
 	requestCount int32
 
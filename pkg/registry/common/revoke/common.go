@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package revoke provides registry chain elements that reject registrations with revoked tokens and unregister
+// already existing registrations once their tokens get revoked.
+package revoke
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+
+	"github.com/ljkiraly/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
+)
+
+type registrationInfo struct {
+	tokens     []string
+	unregister func()
+}
+
+// registrations - registrations by scoped names, swept each time the revocation list is changed
+type registrations struct {
+	ctx  context.Context
+	list *revocation.List
+	genericsync.Map[string, *registrationInfo]
+}
+
+func newRegistrations(ctx context.Context, list *revocation.List) *registrations {
+	r := &registrations{
+		ctx:  ctx,
+		list: list,
+	}
+
+	updateCh := list.Subscribe(ctx)
+	go func() {
+		for range updateCh {
+			r.sweep()
+		}
+	}()
+
+	return r
+}
+
+// check - returns the tokens of the left side of the path or an error if any of them is revoked
+func (r *registrations) check(ctx context.Context) ([]string, error) {
+	path := grpcmetadata.PathFromContext(ctx)
+	if len(path.PathSegments) == 0 {
+		return nil, nil
+	}
+	var tokens []string
+	for _, segment := range path.PathSegments[:path.Index+1] {
+		if err := r.list.CheckToken(segment.Token); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, segment.Token)
+	}
+	return tokens, nil
+}
+
+func (r *registrations) sweep() {
+	r.Range(func(key string, info *registrationInfo) bool {
+		for _, token := range info.tokens {
+			if r.list.CheckToken(token) == nil {
+				continue
+			}
+			log.FromContext(r.ctx).WithField("revokeServer", "sweep").Warnf("unregistering revoked registration: %s", key)
+			r.Delete(key)
+			info.unregister()
+			break
+		}
+		return true
+	})
+}
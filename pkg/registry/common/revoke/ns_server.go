@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revoke
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/registry/common/begin"
+	"github.com/ljkiraly/sdk/pkg/registry/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
+)
+
+type revokeNSServer struct {
+	*registrations
+}
+
+// NewNetworkServiceRegistryServer - returns a new revoke chain element. It checks the left side of the Path
+// against list on each Register and unregisters the matching NSs each time list is changed.
+// Should be placed after begin and authorize chain elements.
+func NewNetworkServiceRegistryServer(ctx context.Context, list *revocation.List) registry.NetworkServiceRegistryServer {
+	return &revokeNSServer{
+		registrations: newRegistrations(ctx, list),
+	}
+}
+
+func (s *revokeNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	tokens, err := s.check(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	factory := begin.FromContext(ctx)
+	s.Store(tenant.ScopedKey(tenant.FromContext(ctx), resp.GetName()), &registrationInfo{
+		tokens: tokens,
+		unregister: func() {
+			factory.Unregister(begin.CancelContext(s.ctx), begin.ExtendContext(ctx))
+		},
+	})
+
+	return resp, nil
+}
+
+func (s *revokeNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *revokeNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	s.Delete(tenant.ScopedKey(tenant.FromContext(ctx), ns.GetName()))
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
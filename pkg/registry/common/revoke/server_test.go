@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revoke_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/registry/common/begin"
+	"github.com/ljkiraly/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/ljkiraly/sdk/pkg/registry/common/memory"
+	"github.com/ljkiraly/sdk/pkg/registry/common/revoke"
+	"github.com/ljkiraly/sdk/pkg/registry/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/registry/core/chain"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
+)
+
+func genJWT(id, subject string) string {
+	t, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.RegisteredClaims{
+		ID:      id,
+		Subject: subject,
+	}).SignedString([]byte("super secret"))
+	return t
+}
+
+func withPath(ctx context.Context, tokenID string) context.Context {
+	return grpcmetadata.PathWithContext(ctx, &grpcmetadata.Path{
+		Index: 1,
+		PathSegments: []*grpcmetadata.PathSegment{
+			{Token: genJWT(tokenID, "spiffe://test.com/nse")},
+			{Token: genJWT(tokenID+"-nsmgr", "spiffe://test.com/nsmgr")},
+		},
+	})
+}
+
+func findNSEs(ctx context.Context, t *testing.T, mem registry.NetworkServiceEndpointRegistryServer) []string {
+	stream, err := adapters.NetworkServiceEndpointServerToClient(mem).Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+	})
+	require.NoError(t, err)
+
+	var names []string
+	for _, nse := range registry.ReadNetworkServiceEndpointList(stream) {
+		names = append(names, nse.GetName())
+	}
+	return names
+}
+
+func TestRevokeNSEServer_RejectsRevokedTokens(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := chain.NewNetworkServiceEndpointRegistryServer(
+		begin.NewNetworkServiceEndpointRegistryServer(),
+		revoke.NewNetworkServiceEndpointRegistryServer(ctx, revocation.NewList(nil, []string{"token-1"})),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	_, err := server.Register(withPath(ctx, "token-1"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Error(t, err)
+
+	_, err = server.Register(withPath(ctx, "token-2"), &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.NoError(t, err)
+}
+
+func TestRevokeNSEServer_UnregistersRevokedNSEs(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	list := revocation.NewList(nil, nil)
+	mem := memory.NewNetworkServiceEndpointRegistryServer()
+	server := chain.NewNetworkServiceEndpointRegistryServer(
+		begin.NewNetworkServiceEndpointRegistryServer(),
+		revoke.NewNetworkServiceEndpointRegistryServer(ctx, list),
+		mem,
+	)
+
+	_, err := server.Register(withPath(ctx, "token-1"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	_, err = server.Register(withPath(ctx, "token-2"), &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"nse-1", "nse-2"}, findNSEs(ctx, t, mem))
+
+	list.Revoke(nil, []string{"token-1"})
+	require.Eventually(t, func() bool {
+		return len(findNSEs(ctx, t, mem)) == 1
+	}, time.Second, time.Millisecond*10)
+	require.Equal(t, []string{"nse-2"}, findNSEs(ctx, t, mem))
+
+	list.Revoke([]string{"spiffe://test.com/nse"}, nil)
+	require.Eventually(t, func() bool {
+		return len(findNSEs(ctx, t, mem)) == 0
+	}, time.Second, time.Millisecond*10)
+}
+
+func TestRevokeNSServer_UnregistersRevokedNSs(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	list := revocation.NewList(nil, nil)
+	mem := memory.NewNetworkServiceRegistryServer()
+	server := chain.NewNetworkServiceRegistryServer(
+		begin.NewNetworkServiceRegistryServer(),
+		revoke.NewNetworkServiceRegistryServer(ctx, list),
+		mem,
+	)
+
+	_, err := server.Register(withPath(ctx, "token-1"), &registry.NetworkService{Name: "ns-1"})
+	require.NoError(t, err)
+
+	list.Revoke(nil, []string{"token-1"})
+	require.Eventually(t, func() bool {
+		stream, err := adapters.NetworkServiceServerToClient(mem).Find(ctx, &registry.NetworkServiceQuery{
+			NetworkService: &registry.NetworkService{},
+		})
+		require.NoError(t, err)
+		return len(registry.ReadNetworkServiceList(stream)) == 0
+	}, time.Second, time.Millisecond*10)
+
+	_, err = server.Register(withPath(ctx, "token-1"), &registry.NetworkService{Name: "ns-1"})
+	require.Error(t, err)
+}
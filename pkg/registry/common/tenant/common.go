@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenant provides registry chain elements resolving the tenant of the caller and storing it in the context
+// to be used by the memory chain elements for scoping the entries.
+//
+// The tenant is derived only from the authenticated peer SVID, the path tokens and the labels are set by the caller
+// and so cannot select the scope. For the registrations and Finds proxied by the NSMgr the peer is the NSMgr, so the
+// tenant is derived from the NSMgr identity and tenants should not share NSMgrs.
+package tenant
+
+import (
+	"context"
+
+	"github.com/ljkiraly/sdk/pkg/tools/spire"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
+)
+
+// withTenant returns ctx with tenant derived by tenantFunc from the authenticated peer SPIFFE ID. Without the peer
+// SVID it is the shared scope.
+func withTenant(ctx context.Context, tenantFunc tenant.Func) context.Context {
+	var t string
+	if id, err := spire.PeerSpiffeIDFromContext(ctx); err == nil && tenantFunc != nil {
+		t = tenantFunc(id)
+	}
+	return tenant.WithTenant(ctx, t)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/registry/core/next"
+	"github.com/ljkiraly/sdk/pkg/registry/core/streamcontext"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
+)
+
+type tenantNSServer struct {
+	tenantFunc tenant.Func
+}
+
+// NewNetworkServiceRegistryServer creates a new tenant registry.NetworkServiceRegistryServer
+// storing the tenant derived by tenantFunc in the context of Register, Find and Unregister.
+func NewNetworkServiceRegistryServer(tenantFunc tenant.Func) registry.NetworkServiceRegistryServer {
+	return &tenantNSServer{
+		tenantFunc: tenantFunc,
+	}
+}
+
+func (s *tenantNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	ctx = withTenant(ctx, s.tenantFunc)
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *tenantNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	ctx := withTenant(server.Context(), s.tenantFunc)
+	server = streamcontext.NetworkServiceRegistryFindServer(ctx, server)
+	return next.NetworkServiceRegistryServer(ctx).Find(query, server)
+}
+
+func (s *tenantNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	ctx = withTenant(ctx, s.tenantFunc)
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	registrytenant "github.com/ljkiraly/sdk/pkg/registry/common/tenant"
+	"github.com/ljkiraly/sdk/pkg/registry/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/registry/core/next"
+	"github.com/ljkiraly/sdk/pkg/registry/utils/checks/checkcontext"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
+)
+
+func TestTenantNSServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	var actual string
+	client := adapters.NetworkServiceServerToClient(next.NewNetworkServiceRegistryServer(
+		registrytenant.NewNetworkServiceRegistryServer(tenant.TrustDomain()),
+		checkcontext.NewNSServer(t, func(_ *testing.T, ctx context.Context) {
+			actual = tenant.FromContext(ctx)
+		}),
+	))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	callerCtx := withPeer(ctx, t, "spiffe://tenant-a.org/nsmgr")
+
+	_, err := client.Register(callerCtx, &registry.NetworkService{Name: "ns"})
+	require.NoError(t, err)
+	require.Equal(t, "tenant-a.org", actual)
+
+	_, err = client.Find(withPeer(ctx, t, "spiffe://tenant-b.org/nsmgr"), &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{Name: "ns"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "tenant-b.org", actual)
+
+	_, err = client.Unregister(callerCtx, &registry.NetworkService{Name: "ns"})
+	require.NoError(t, err)
+	require.Equal(t, "tenant-a.org", actual)
+
+	// No authenticated peer - the shared scope
+	_, err = client.Register(withPathCaller(ctx, t, "spiffe://tenant-a.org/nse"), &registry.NetworkService{Name: "ns"})
+	require.NoError(t, err)
+	require.Empty(t, actual)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/registry/core/next"
+	"github.com/ljkiraly/sdk/pkg/registry/core/streamcontext"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
+)
+
+type tenantNSEServer struct {
+	tenantFunc tenant.Func
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new tenant registry.NetworkServiceEndpointRegistryServer
+// storing the tenant derived by tenantFunc in the context of Register, Find and Unregister.
+func NewNetworkServiceEndpointRegistryServer(tenantFunc tenant.Func) registry.NetworkServiceEndpointRegistryServer {
+	return &tenantNSEServer{
+		tenantFunc: tenantFunc,
+	}
+}
+
+func (s *tenantNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	ctx = withTenant(ctx, s.tenantFunc)
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *tenantNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	ctx := withTenant(server.Context(), s.tenantFunc)
+	server = streamcontext.NetworkServiceEndpointRegistryFindServer(ctx, server)
+	return next.NetworkServiceEndpointRegistryServer(ctx).Find(query, server)
+}
+
+func (s *tenantNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	ctx = withTenant(ctx, s.tenantFunc)
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk/pkg/registry/common/grpcmetadata"
+	registrytenant "github.com/ljkiraly/sdk/pkg/registry/common/tenant"
+	"github.com/ljkiraly/sdk/pkg/registry/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/registry/core/next"
+	"github.com/ljkiraly/sdk/pkg/registry/utils/checks/checkcontext"
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
+)
+
+// withPeer returns ctx with the authenticated peer having SVID with spiffeID
+func withPeer(ctx context.Context, t *testing.T, spiffeID string) context.Context {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	id, err := url.Parse(spiffeID)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{id},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return peer.NewContext(ctx, &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+			},
+		},
+	})
+}
+
+// withPathCaller returns ctx with the path having the first token issued for spiffeID
+func withPathCaller(ctx context.Context, t *testing.T, spiffeID string) context.Context {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: spiffeID}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	return grpcmetadata.PathWithContext(ctx, &grpcmetadata.Path{
+		PathSegments: []*grpcmetadata.PathSegment{{Token: token}},
+	})
+}
+
+func newNSE() *registry.NetworkServiceEndpoint {
+	return &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{"ns"},
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"ns": {Labels: map[string]string{"tenant": "tenant-b"}},
+		},
+	}
+}
+
+func TestTenantNSEServer_Register(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	var actual string
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		registrytenant.NewNetworkServiceEndpointRegistryServer(tenant.PathSegment(1)),
+		checkcontext.NewNSEServer(t, func(_ *testing.T, ctx context.Context) {
+			actual = tenant.FromContext(ctx)
+		}),
+	)
+
+	ctx := withPeer(context.Background(), t, "spiffe://example.org/ns/tenant-a/sa/nsmgr")
+	_, err := server.Register(ctx, newNSE())
+	require.NoError(t, err)
+	require.Equal(t, "tenant-a", actual)
+
+	_, err = server.Unregister(ctx, newNSE())
+	require.NoError(t, err)
+	require.Equal(t, "tenant-a", actual)
+
+	// The path token and the labels are set by the caller, so they cannot select the tenant
+	ctx = withPathCaller(withPeer(context.Background(), t, "spiffe://example.org/nsmgr"), t, "spiffe://example.org/ns/tenant-b/sa/nse")
+	_, err = server.Register(ctx, newNSE())
+	require.NoError(t, err)
+	require.Empty(t, actual)
+
+	// No authenticated peer - the shared scope
+	_, err = server.Register(withPathCaller(context.Background(), t, "spiffe://example.org/ns/tenant-b/sa/nse"), newNSE())
+	require.NoError(t, err)
+	require.Empty(t, actual)
+}
+
+func TestTenantNSEServer_Find(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	var actual string
+	client := adapters.NetworkServiceEndpointServerToClient(next.NewNetworkServiceEndpointRegistryServer(
+		registrytenant.NewNetworkServiceEndpointRegistryServer(tenant.TrustDomain()),
+		checkcontext.NewNSEServer(t, func(_ *testing.T, ctx context.Context) {
+			actual = tenant.FromContext(ctx)
+		}),
+	))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := client.Find(withPeer(ctx, t, "spiffe://tenant-a.org/nsmgr"), &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: newNSE(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "tenant-a.org", actual)
+
+	_, err = client.Find(withPathCaller(ctx, t, "spiffe://tenant-b.org/nsmgr"), &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: newNSE(),
+	})
+	require.NoError(t, err)
+	require.Empty(t, actual)
+}
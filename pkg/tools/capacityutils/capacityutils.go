@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package capacityutils provides helpers to advertise the NSE capacity in the registry
+package capacityutils
+
+import (
+	"strconv"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+const (
+	// CapacityLabel is the NSE label with the max number of connections the NSE accepts
+	CapacityLabel = "nsm.capacity"
+	// LoadLabel is the NSE label with the current number of connections on the NSE
+	LoadLabel = "nsm.load"
+)
+
+func intLabel(nse *registry.NetworkServiceEndpoint, key string) (int, bool) {
+	for _, labels := range nse.GetNetworkServiceLabels() {
+		if value, ok := labels.GetLabels()[key]; ok {
+			if rv, err := strconv.Atoi(value); err == nil {
+				return rv, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// IsFull returns true if nse advertises the capacity and its load has reached it
+func IsFull(nse *registry.NetworkServiceEndpoint) bool {
+	capacity, ok := intLabel(nse, CapacityLabel)
+	if !ok {
+		return false
+	}
+	load, _ := intLabel(nse, LoadLabel)
+	return load >= capacity
+}
+
+// WithLoad returns a copy of nse advertising capacity and load for all of its network services
+func WithLoad(nse *registry.NetworkServiceEndpoint, capacity, load int) *registry.NetworkServiceEndpoint {
+	rv := nse.Clone()
+	if rv.NetworkServiceLabels == nil {
+		rv.NetworkServiceLabels = make(map[string]*registry.NetworkServiceLabels)
+	}
+	for _, name := range rv.GetNetworkServiceNames() {
+		if rv.NetworkServiceLabels[name] == nil {
+			rv.NetworkServiceLabels[name] = new(registry.NetworkServiceLabels)
+		}
+	}
+	for _, labels := range rv.NetworkServiceLabels {
+		if labels.Labels == nil {
+			labels.Labels = make(map[string]string)
+		}
+		labels.Labels[CapacityLabel] = strconv.Itoa(capacity)
+		labels.Labels[LoadLabel] = strconv.Itoa(load)
+	}
+	return rv
+}
+
+// Prefer returns nses which are not full. If all of them are full, all of them are returned, so the NSE is still able
+// to preempt a lower priority connection.
+func Prefer(nses []*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+	var rv []*registry.NetworkServiceEndpoint
+	for _, nse := range nses {
+		if !IsFull(nse) {
+			rv = append(rv, nse)
+		}
+	}
+	if len(rv) == 0 {
+		return nses
+	}
+	return rv
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/ljkiraly/sdk/pkg/tools/dnsutils"
 	"github.com/ljkiraly/sdk/pkg/tools/dnsutils/next"
+	"github.com/ljkiraly/sdk/pkg/tools/dnsutils/querylog"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
 )
 
@@ -43,6 +44,7 @@ func (h *dnsCacheHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m
 		v := val.Copy()
 		if validateMsg(v) {
 			v.Id = m.Id
+			querylog.SetCacheHit(ctx)
 			if err := rw.WriteMsg(v); err != nil {
 				log.FromContext(ctx).WithField("dnsCacheHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
 				dns.HandleFailed(rw, v)
@@ -0,0 +1,214 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package domainrouting forwards DNS queries only to the servers of the DNS configs whose search domain is the
+// longest suffix of the query name. Queries matching no search domain go to the fallback servers and to the servers
+// of the DNS configs having no search domains.
+package domainrouting
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/tools/clienturlctx"
+	"github.com/ljkiraly/sdk/pkg/tools/dnsutils"
+	"github.com/ljkiraly/sdk/pkg/tools/dnsutils/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+// FallbackRoute is a name of the route used for the queries matching no search domain
+const FallbackRoute = "."
+
+type domainRoutingHandler struct {
+	configs         *genericsync.Map[string, []*networkservice.DNSConfig]
+	fallbackServers []string
+	resolvConfPath  string
+}
+
+// route is a search domain with the servers of all DNS configs having it
+type route struct {
+	domain  string
+	servers []string
+}
+
+func (h *domainRoutingHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	if len(m.Question) == 0 {
+		dns.HandleFailed(rw, m)
+		return
+	}
+
+	routes, fallback := h.routes()
+	name := dns.Fqdn(strings.ToLower(m.Question[0].Name))
+
+	// Routed queries never leak to the other servers, even if the route fails
+	if r := match(routes, name); r != nil {
+		if resp := h.exchange(ctx, m, r); resp != nil {
+			h.write(ctx, rw, m, resp)
+			return
+		}
+		dns.HandleFailed(rw, m)
+		return
+	}
+
+	// Single label names are expanded with the search domains, each expansion goes to the servers of its domain
+	for i := 0; i < len(routes) && dns.CountLabel(name) == 1; i++ {
+		newMsg := m.Copy()
+		newMsg.Question[0].Name = dns.Fqdn(name + routes[i].domain)
+		if resp := h.exchange(ctx, newMsg, routes[i]); resp != nil && resp.Rcode == dns.RcodeSuccess {
+			resp.Question = m.Question
+			h.write(ctx, rw, m, resp)
+			return
+		}
+	}
+
+	if resp := h.exchange(ctx, m, fallback); resp != nil {
+		h.write(ctx, rw, m, resp)
+		return
+	}
+
+	dns.HandleFailed(rw, m)
+}
+
+// routes collects the search domains with their servers sorted from the longest domain to the shortest, and the
+// fallback route with the servers of the configs having no search domains
+func (h *domainRoutingHandler) routes() (result []*route, fallback *route) {
+	index := make(map[string]*route)
+	fallback = &route{domain: FallbackRoute}
+
+	h.configs.Range(func(_ string, value []*networkservice.DNSConfig) bool {
+		for _, conf := range value {
+			if len(conf.GetSearchDomains()) == 0 {
+				fallback.servers = append(fallback.servers, conf.GetDnsServerIps()...)
+				continue
+			}
+			for _, domain := range conf.GetSearchDomains() {
+				domain = strings.Trim(strings.ToLower(domain), ".")
+				if domain == "" {
+					continue
+				}
+				r, ok := index[domain]
+				if !ok {
+					r = &route{domain: domain}
+					index[domain] = r
+					result = append(result, r)
+				}
+				r.servers = append(r.servers, conf.GetDnsServerIps()...)
+			}
+		}
+		return true
+	})
+
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].domain) != len(result[j].domain) {
+			return len(result[i].domain) > len(result[j].domain)
+		}
+		return result[i].domain < result[j].domain
+	})
+	fallback.servers = append(fallback.servers, h.fallbackServers...)
+
+	return result, fallback
+}
+
+// match returns the route with the longest search domain being a suffix of the fqdn
+func match(routes []*route, fqdn string) *route {
+	var result *route
+	for _, r := range routes {
+		if !dns.IsSubDomain(r.domain+".", fqdn) {
+			continue
+		}
+		if result == nil || len(r.domain) > len(result.domain) {
+			result = r
+		}
+	}
+	return result
+}
+
+// exchange sends the query to the servers of the route over UDP and then over TCP
+func (h *domainRoutingHandler) exchange(ctx context.Context, m *dns.Msg, r *route) *dns.Msg {
+	if len(r.servers) == 0 {
+		return nil
+	}
+
+	for _, scheme := range []string{"udp", "tcp"} {
+		dnsIPs := make([]url.URL, 0, len(r.servers))
+		for _, ip := range r.servers {
+			dnsIPs = append(dnsIPs, url.URL{Scheme: scheme, Host: ip})
+		}
+
+		rw := &responseWriter{}
+		next.Handler(ctx).ServeDNS(clienturlctx.WithClientURLs(ctx, dnsIPs), rw, m)
+
+		if rw.Response != nil {
+			recordQuery(ctx, r.domain, rw.Response.Rcode)
+			return rw.Response
+		}
+	}
+
+	recordQuery(ctx, r.domain, dns.RcodeServerFailure)
+	return nil
+}
+
+func (h *domainRoutingHandler) write(ctx context.Context, rw dns.ResponseWriter, m, resp *dns.Msg) {
+	if err := rw.WriteMsg(resp); err != nil {
+		log.FromContext(ctx).WithField("domainRoutingHandler", "ServeDNS").Warnf("got an error during writing the message: %v", err.Error())
+		dns.HandleFailed(rw, m)
+	}
+}
+
+// readNameservers returns the nameservers of the resolv.conf file
+func readNameservers(path string) ([]string, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read resolv.conf file: %s", path)
+	}
+	var result []string
+	for _, l := range strings.Split(string(b), "\n") {
+		words := strings.Fields(l)
+		if len(words) > 1 && words[0] == "nameserver" {
+			result = append(result, words[1])
+		}
+	}
+	return result, nil
+}
+
+// NewDNSHandler creates a new dns handler that routes queries by the search domains of DNS configs. Unless
+// WithFallbackServers is set, the fallback servers are the nameservers of the resolv.conf read at creation time.
+func NewDNSHandler(configs *genericsync.Map[string, []*networkservice.DNSConfig], opts ...Option) dnsutils.Handler {
+	var h = &domainRoutingHandler{
+		configs:        configs,
+		resolvConfPath: "/etc/resolv.conf",
+	}
+	for _, o := range opts {
+		o(h)
+	}
+	if h.fallbackServers == nil {
+		var err error
+		if h.fallbackServers, err = readNameservers(h.resolvConfPath); err != nil {
+			log.FromContext(context.Background()).WithField("domainRoutingHandler", "NewDNSHandler").Warnf("no fallback servers: %v", err.Error())
+		}
+	}
+	return h
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domainrouting_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk/pkg/tools/clienturlctx"
+	"github.com/ljkiraly/sdk/pkg/tools/dnsutils/domainrouting"
+	"github.com/ljkiraly/sdk/pkg/tools/dnsutils/next"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
+
+// upstreamHandler answers the queries for the names known by the servers, it ignores TCP
+type upstreamHandler struct {
+	records map[string][]string
+	queries []string
+}
+
+func (h *upstreamHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	for _, u := range clienturlctx.ClientURLs(ctx) {
+		if u.Scheme != "udp" {
+			continue
+		}
+		h.queries = append(h.queries, u.Host+" "+strings.ToLower(m.Question[0].Name))
+		for _, name := range h.records[u.Host] {
+			if strings.EqualFold(name, m.Question[0].Name) {
+				resp := new(dns.Msg).SetReply(m)
+				_ = rw.WriteMsg(resp)
+				return
+			}
+		}
+	}
+	_ = rw.WriteMsg(new(dns.Msg).SetRcode(m, dns.RcodeNameError))
+}
+
+func TestDomainRouting(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	configs := new(genericsync.Map[string, []*networkservice.DNSConfig])
+	configs.Store("1", []*networkservice.DNSConfig{
+		{
+			SearchDomains: []string{"corp-a"},
+			DnsServerIps:  []string{"10.0.0.1"},
+		},
+	})
+	configs.Store("2", []*networkservice.DNSConfig{
+		{
+			SearchDomains: []string{"corp-b", "eu.corp-a"},
+			DnsServerIps:  []string{"10.0.0.2"},
+		},
+	})
+
+	upstream := &upstreamHandler{
+		records: map[string][]string{
+			"10.0.0.1": {"db.corp-a.", "cache.corp-a."},
+			"10.0.0.2": {"db.eu.corp-a.", "db.corp-b."},
+			"8.8.8.8":  {"example.com.", "db.corp-a."},
+		},
+	}
+	handler := next.NewDNSHandler(
+		domainrouting.NewDNSHandler(configs, domainrouting.WithFallbackServers("8.8.8.8")),
+		upstream,
+	)
+
+	for _, testCase := range []struct {
+		name    string
+		rcode   int
+		queries []string
+	}{
+		{name: "db.corp-a.", rcode: dns.RcodeSuccess, queries: []string{"10.0.0.1 db.corp-a."}},
+		{name: "DB.EU.Corp-A.", rcode: dns.RcodeSuccess, queries: []string{"10.0.0.2 db.eu.corp-a."}},
+		{name: "db.corp-b.", rcode: dns.RcodeSuccess, queries: []string{"10.0.0.2 db.corp-b."}},
+		{name: "example.com.", rcode: dns.RcodeSuccess, queries: []string{"8.8.8.8 example.com."}},
+		// Not found in the route is not forwarded to the fallback
+		{name: "web.corp-a.", rcode: dns.RcodeNameError, queries: []string{"10.0.0.1 web.corp-a."}},
+	} {
+		upstream.queries = nil
+
+		m := new(dns.Msg).SetQuestion(testCase.name, dns.TypeA)
+		rw := &responseWriter{}
+		handler.ServeDNS(ctx, rw, m)
+
+		require.NotNil(t, rw.Response, testCase.name)
+		require.Equal(t, testCase.rcode, rw.Response.Rcode, testCase.name)
+		require.Equal(t, m.Question, rw.Response.Question, testCase.name)
+		if testCase.queries != nil {
+			require.Equal(t, testCase.queries, upstream.queries, testCase.name)
+		}
+	}
+
+	// Single label names are expanded only with the search domains of the routes
+	upstream.queries = nil
+	rw := &responseWriter{}
+	handler.ServeDNS(ctx, rw, new(dns.Msg).SetQuestion("cache.", dns.TypeA))
+	require.Equal(t, dns.RcodeSuccess, rw.Response.Rcode)
+	require.Contains(t, upstream.queries, "10.0.0.1 cache.corp-a.")
+	require.NotContains(t, upstream.queries, "8.8.8.8 cache.")
+}
+
+func TestDomainRouting_Fallback(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resolvConfPath := filepath.Join(t.TempDir(), "resolv.conf")
+	require.NoError(t, os.WriteFile(resolvConfPath, []byte("# system resolver\nnameserver 8.8.8.8\nsearch example.com\n"), os.ModePerm))
+
+	configs := new(genericsync.Map[string, []*networkservice.DNSConfig])
+	configs.Store("1", []*networkservice.DNSConfig{
+		{
+			DnsServerIps: []string{"10.0.0.1"},
+		},
+	})
+	configs.Store("2", []*networkservice.DNSConfig{
+		{
+			SearchDomains: []string{"b.corp", "a.corp", "eu.a.corp"},
+			DnsServerIps:  []string{"10.0.0.2"},
+		},
+	})
+
+	upstream := &upstreamHandler{
+		records: map[string][]string{
+			"10.0.0.1": {"db.nsm."},
+			"10.0.0.2": {"db.a.corp.", "db.b.corp.", "db.eu.a.corp."},
+			"8.8.8.8":  {"example.com."},
+		},
+	}
+	handler := next.NewDNSHandler(
+		domainrouting.NewDNSHandler(configs, domainrouting.WithResolvConfPath(resolvConfPath)),
+		upstream,
+	)
+
+	// Servers of the configs without search domains and the resolv.conf servers serve the unmatched names
+	for name, queries := range map[string][]string{
+		"db.nsm.":      {"10.0.0.1 db.nsm."},
+		"example.com.": {"10.0.0.1 example.com.", "8.8.8.8 example.com."},
+	} {
+		upstream.queries = nil
+		rw := &responseWriter{}
+		handler.ServeDNS(ctx, rw, new(dns.Msg).SetQuestion(name, dns.TypeA))
+		require.Equal(t, dns.RcodeSuccess, rw.Response.Rcode, name)
+		require.Equal(t, queries, upstream.queries, name)
+	}
+
+	// Single label names are expanded from the longest search domain to the shortest, then by name
+	for i := 0; i < 10; i++ {
+		upstream.queries = nil
+		rw := &responseWriter{}
+		handler.ServeDNS(ctx, rw, new(dns.Msg).SetQuestion("web.", dns.TypeA))
+		require.Equal(t, []string{
+			"10.0.0.2 web.eu.a.corp.",
+			"10.0.0.2 web.a.corp.",
+			"10.0.0.2 web.b.corp.",
+			"10.0.0.1 web.",
+			"8.8.8.8 web.",
+		}, upstream.queries)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domainrouting
+
+import (
+	"context"
+	"sync"
+
+	"github.com/miekg/dns"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/opentelemetry"
+)
+
+const queriesMetric = "dns_route_queries"
+
+var (
+	queriesOnce    sync.Once
+	queriesCounter metric.Int64Counter
+)
+
+// recordQuery counts the query forwarded by the route with the response code
+func recordQuery(ctx context.Context, domain string, rcode int) {
+	if !opentelemetry.IsEnabled() {
+		return
+	}
+	queriesOnce.Do(func() {
+		var err error
+		queriesCounter, err = otel.Meter("").Int64Counter(queriesMetric,
+			metric.WithDescription("number of DNS queries forwarded by the search domain route"))
+		if err != nil {
+			log.FromContext(ctx).Warnf("failed to create %s counter: %s", queriesMetric, err.Error())
+		}
+	})
+	if queriesCounter == nil {
+		return
+	}
+	queriesCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("route", domain),
+		attribute.String("rcode", dns.RcodeToString[rcode]),
+	))
+}
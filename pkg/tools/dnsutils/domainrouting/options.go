@@ -0,0 +1,36 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domainrouting
+
+// Option modifies default domain routing dns handler values
+type Option func(*domainRoutingHandler)
+
+// WithFallbackServers sets DNS servers for the queries matching no search domain. Usually these are the servers
+// of the original system resolver.
+func WithFallbackServers(ips ...string) Option {
+	return func(h *domainRoutingHandler) {
+		h.fallbackServers = ips
+	}
+}
+
+// WithResolvConfPath sets the path of the resolv.conf file the default fallback servers are read from.
+// Default is "/etc/resolv.conf".
+func WithResolvConfPath(path string) Option {
+	return func(h *domainRoutingHandler) {
+		h.resolvConfPath = path
+	}
+}
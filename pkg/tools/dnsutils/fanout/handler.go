@@ -29,6 +29,7 @@ import (
 	"github.com/ljkiraly/sdk/pkg/tools/clienturlctx"
 	"github.com/ljkiraly/sdk/pkg/tools/dnsutils"
 	"github.com/ljkiraly/sdk/pkg/tools/dnsutils/next"
+	"github.com/ljkiraly/sdk/pkg/tools/dnsutils/querylog"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
 )
 
@@ -38,7 +39,7 @@ type fanoutHandler struct {
 
 func (h *fanoutHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, msg *dns.Msg) {
 	var connectTO = clienturlctx.ClientURLs(ctx)
-	var responseCh = make(chan *dns.Msg, len(connectTO))
+	var responseCh = make(chan *response, len(connectTO))
 
 	deadline, _ := ctx.Deadline()
 	timeout := time.Until(deadline)
@@ -73,7 +74,7 @@ func (h *fanoutHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, msg
 				return
 			}
 
-			responseCh <- resp
+			responseCh <- &response{msg: resp, address: address}
 		}(&connectTO[i], msg.Copy())
 	}
 
@@ -83,8 +84,9 @@ func (h *fanoutHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, msg
 		dns.HandleFailed(rw, msg)
 		return
 	}
+	querylog.SetUpstream(ctx, resp.address)
 
-	if err := rw.WriteMsg(resp); err != nil {
+	if err := rw.WriteMsg(resp.msg); err != nil {
 		log.FromContext(ctx).WithField("fanoutHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
 		dns.HandleFailed(rw, msg)
 		return
@@ -93,7 +95,13 @@ func (h *fanoutHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, msg
 	next.Handler(ctx).ServeDNS(ctx, rw, msg)
 }
 
-func (h *fanoutHandler) waitResponse(ctx context.Context, respCh <-chan *dns.Msg) *dns.Msg {
+// response is a message received from the address
+type response struct {
+	msg     *dns.Msg
+	address string
+}
+
+func (h *fanoutHandler) waitResponse(ctx context.Context, respCh <-chan *response) *response {
 	var respCount = cap(respCh)
 	for {
 		select {
@@ -108,7 +116,7 @@ func (h *fanoutHandler) waitResponse(ctx context.Context, respCh <-chan *dns.Msg
 				}
 				continue
 			}
-			if resp.Rcode == dns.RcodeSuccess {
+			if resp.msg.Rcode == dns.RcodeSuccess {
 				return resp
 			}
 			if respCount == 0 {
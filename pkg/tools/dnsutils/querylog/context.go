@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querylog
+
+import (
+	"context"
+	"time"
+)
+
+type contextKeyType string
+
+const recordKey contextKeyType = "QueryRecord"
+
+// Record describes a single DNS query served by the handler
+type Record struct {
+	// Stage is a name of the handler position in the chain
+	Stage string
+	// Name is a queried domain name
+	Name string
+	// Type is a queried record type, e.g. "A"
+	Type string
+	// Rcode is a response code, "NONE" if no response has been written
+	Rcode string
+	// Upstream is an address of the DNS server answered the query
+	Upstream string
+	// CacheHit is true if the response has been served from the cache
+	CacheHit bool
+	// Latency is the time spent in the rest of the chain
+	Latency time.Duration
+
+	parent *Record
+}
+
+func withRecord(parent context.Context, r *Record) context.Context {
+	if parent == nil {
+		panic("cannot create context from nil parent")
+	}
+	r.parent, _ = parent.Value(recordKey).(*Record)
+	return context.WithValue(parent, recordKey, r)
+}
+
+// SetUpstream stores the address of the DNS server answered the query in the records of the context
+func SetUpstream(ctx context.Context, address string) {
+	for r, _ := ctx.Value(recordKey).(*Record); r != nil; r = r.parent {
+		r.Upstream = address
+	}
+}
+
+// SetCacheHit marks the records of the context as served from the cache
+func SetCacheHit(ctx context.Context) {
+	for r, _ := ctx.Value(recordKey).(*Record); r != nil; r = r.parent {
+		r.CacheHit = true
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package querylog records name, type, response code, upstream, latency and cache usage of each DNS query.
+// Records are exposed as OpenTelemetry metrics and as an optional sampled structured log. The handler may be
+// inserted anywhere in the chain, handlers after it report the upstream and cache hits with SetUpstream and
+// SetCacheHit.
+package querylog
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/miekg/dns"
+
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/dnsutils"
+	"github.com/ljkiraly/sdk/pkg/tools/dnsutils/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+const noRcode = "NONE"
+
+type queryLogHandler struct {
+	stage      string
+	sampleRate float64
+	recorders  []func(context.Context, *Record)
+}
+
+func (h *queryLogHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	r := &Record{
+		Stage: h.stage,
+		Rcode: noRcode,
+	}
+	if len(m.Question) > 0 {
+		r.Name = m.Question[0].Name
+		r.Type = dns.TypeToString[m.Question[0].Qtype]
+	}
+
+	wrapper := &responseWriter{
+		ResponseWriter: rw,
+		record:         r,
+	}
+
+	clk := clock.FromContext(ctx)
+	start := clk.Now()
+	next.Handler(ctx).ServeDNS(withRecord(ctx, r), wrapper, m)
+	r.Latency = clk.Since(start)
+
+	recordMetrics(ctx, r)
+	// Sampling only limits the log volume, it is not security-sensitive
+	// #nosec G404
+	if h.sampleRate > 0 && rand.Float64() < h.sampleRate {
+		logRecord(ctx, r)
+	}
+	for _, recorder := range h.recorders {
+		recorder(ctx, r)
+	}
+}
+
+func logRecord(ctx context.Context, r *Record) {
+	log.FromContext(ctx).
+		WithField("queryLogHandler", r.Stage).
+		WithField("name", r.Name).
+		WithField("type", r.Type).
+		WithField("rcode", r.Rcode).
+		WithField("upstream", r.Upstream).
+		WithField("cacheHit", r.CacheHit).
+		WithField("latency", r.Latency).
+		Infof("dns query")
+}
+
+// NewDNSHandler creates a new dns handler that records DNS queries
+func NewDNSHandler(opts ...Option) dnsutils.Handler {
+	var h = &queryLogHandler{}
+	for _, o := range opts {
+		o(h)
+	}
+	return h
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querylog_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/clockmock"
+	"github.com/ljkiraly/sdk/pkg/tools/dnsutils/cache"
+	"github.com/ljkiraly/sdk/pkg/tools/dnsutils/next"
+	"github.com/ljkiraly/sdk/pkg/tools/dnsutils/querylog"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
+
+// upstreamHandler answers A queries for example.com. as the 10.0.0.1 server
+type upstreamHandler struct{}
+
+func (h *upstreamHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	querylog.SetUpstream(ctx, "10.0.0.1:53")
+
+	resp := new(dns.Msg).SetReply(m)
+	if m.Question[0].Name != "example.com." {
+		resp.Rcode = dns.RcodeNameError
+		_ = rw.WriteMsg(resp)
+		return
+	}
+	resp.Answer = append(resp.Answer, &dns.A{
+		Hdr: dns.RR_Header{Name: m.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 3600},
+		A:   net.ParseIP("1.1.1.1"),
+	})
+	_ = rw.WriteMsg(resp)
+}
+
+type recorder struct {
+	mu      sync.Mutex
+	records []querylog.Record
+}
+
+func (r *recorder) record(_ context.Context, record *querylog.Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, *record)
+}
+
+func (r *recorder) pop() []querylog.Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := r.records
+	r.records = nil
+	return result
+}
+
+func TestQueryLog(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	rec := new(recorder)
+	handler := next.NewDNSHandler(
+		querylog.NewDNSHandler(querylog.WithStage("server"), querylog.WithRecorder(rec.record), querylog.WithLogSampleRate(1)),
+		cache.NewDNSHandler(),
+		querylog.NewDNSHandler(querylog.WithStage("upstream"), querylog.WithRecorder(rec.record)),
+		&upstreamHandler{},
+	)
+
+	query := func(name string) *dns.Msg {
+		rw := &responseWriter{}
+		handler.ServeDNS(ctx, rw, new(dns.Msg).SetQuestion(name, dns.TypeA))
+		require.NotNil(t, rw.Response)
+		return rw.Response
+	}
+
+	require.Equal(t, dns.RcodeSuccess, query("example.com.").Rcode)
+	records := rec.pop()
+	require.Len(t, records, 2)
+	for i, stage := range []string{"upstream", "server"} {
+		require.Equal(t, stage, records[i].Stage)
+		require.Equal(t, "example.com.", records[i].Name)
+		require.Equal(t, "A", records[i].Type)
+		require.Equal(t, "NOERROR", records[i].Rcode)
+		require.Equal(t, "10.0.0.1:53", records[i].Upstream)
+		require.False(t, records[i].CacheHit)
+		require.Positive(t, records[i].Latency)
+	}
+
+	// The second query is served from the cache and doesn't reach the upstream stage
+	require.Equal(t, dns.RcodeSuccess, query("example.com.").Rcode)
+	records = rec.pop()
+	require.Len(t, records, 1)
+	require.Equal(t, "server", records[0].Stage)
+	require.True(t, records[0].CacheHit)
+	require.Empty(t, records[0].Upstream)
+
+	require.Equal(t, dns.RcodeNameError, query("unknown.com.").Rcode)
+	records = rec.pop()
+	require.Len(t, records, 2)
+	require.Equal(t, "NXDOMAIN", records[1].Rcode)
+	require.False(t, records[1].CacheHit)
+}
+
+// slowHandler answers the queries after advancing the mock clock by delay
+type slowHandler struct {
+	clock *clockmock.Mock
+	delay time.Duration
+}
+
+func (h *slowHandler) ServeDNS(_ context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	h.clock.Add(h.delay)
+	_ = rw.WriteMsg(new(dns.Msg).SetReply(m))
+}
+
+func TestQueryLog_Latency(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	rec := new(recorder)
+	handler := next.NewDNSHandler(
+		querylog.NewDNSHandler(querylog.WithRecorder(rec.record)),
+		&slowHandler{clock: clockMock, delay: 50 * time.Millisecond},
+	)
+
+	handler.ServeDNS(ctx, &responseWriter{}, new(dns.Msg).SetQuestion("example.com.", dns.TypeA))
+	records := rec.pop()
+	require.Len(t, records, 1)
+	require.Equal(t, 50*time.Millisecond, records[0].Latency)
+}
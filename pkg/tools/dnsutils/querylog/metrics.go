@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querylog
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/opentelemetry"
+)
+
+const (
+	queriesMetric  = "dns_queries"
+	durationMetric = "dns_query_duration"
+)
+
+var (
+	instrumentsOnce sync.Once
+	queriesCounter  metric.Int64Counter
+	durationHist    metric.Float64Histogram
+)
+
+// recordMetrics records the query into the counter and the latency histogram. The query name is not an attribute
+// to keep the cardinality bounded.
+func recordMetrics(ctx context.Context, r *Record) {
+	if !opentelemetry.IsEnabled() {
+		return
+	}
+	instrumentsOnce.Do(func() {
+		var err error
+		meter := otel.Meter("")
+		if queriesCounter, err = meter.Int64Counter(queriesMetric,
+			metric.WithDescription("number of served DNS queries")); err != nil {
+			log.FromContext(ctx).Warnf("failed to create %s counter: %s", queriesMetric, err.Error())
+		}
+		if durationHist, err = meter.Float64Histogram(durationMetric,
+			metric.WithDescription("time spent serving DNS queries"),
+			metric.WithUnit("ms")); err != nil {
+			log.FromContext(ctx).Warnf("failed to create %s histogram: %s", durationMetric, err.Error())
+		}
+	})
+
+	attributes := metric.WithAttributes(
+		attribute.String("stage", r.Stage),
+		attribute.String("type", r.Type),
+		attribute.String("rcode", r.Rcode),
+		attribute.String("upstream", r.Upstream),
+		attribute.String("cache_hit", strconv.FormatBool(r.CacheHit)),
+	)
+	if queriesCounter != nil {
+		queriesCounter.Add(ctx, 1, attributes)
+	}
+	if durationHist != nil {
+		durationHist.Record(ctx, float64(r.Latency)/float64(time.Millisecond), attributes)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querylog
+
+import (
+	"context"
+)
+
+// Option modifies default query log dns handler values
+type Option func(*queryLogHandler)
+
+// WithStage sets a name of the handler position in the chain. It distinguishes records of several query log
+// handlers in the same chain.
+func WithStage(stage string) Option {
+	return func(h *queryLogHandler) {
+		h.stage = stage
+	}
+}
+
+// WithLogSampleRate enables the structured query log for the rate (0..1] part of the queries. Disabled by default.
+func WithLogSampleRate(rate float64) Option {
+	return func(h *queryLogHandler) {
+		h.sampleRate = rate
+	}
+}
+
+// WithRecorder adds a function called with the record of each query
+func WithRecorder(recorder func(ctx context.Context, r *Record)) Option {
+	return func(h *queryLogHandler) {
+		h.recorders = append(h.recorders, recorder)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querylog
+
+import (
+	"github.com/miekg/dns"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	record *Record
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	if m != nil {
+		r.record.Rcode = dns.RcodeToString[m.Rcode]
+	}
+	return r.ResponseWriter.WriteMsg(m)
+}
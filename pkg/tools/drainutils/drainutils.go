@@ -0,0 +1,66 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drainutils provides helpers to mark NSEs as draining in the registry
+package drainutils
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// Label is the NSE label marking the NSE as draining. Draining NSEs are not selected for the new connections.
+const Label = "nsm.draining"
+
+// IsDraining returns true if nse is marked as draining for any of its network services
+func IsDraining(nse *registry.NetworkServiceEndpoint) bool {
+	for _, labels := range nse.GetNetworkServiceLabels() {
+		if labels.GetLabels()[Label] == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// Mark returns a copy of nse marked as draining for all of its network services
+func Mark(nse *registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	rv := nse.Clone()
+	if rv.NetworkServiceLabels == nil {
+		rv.NetworkServiceLabels = make(map[string]*registry.NetworkServiceLabels)
+	}
+	for _, name := range rv.GetNetworkServiceNames() {
+		if rv.NetworkServiceLabels[name] == nil {
+			rv.NetworkServiceLabels[name] = new(registry.NetworkServiceLabels)
+		}
+	}
+	for _, labels := range rv.NetworkServiceLabels {
+		if labels.Labels == nil {
+			labels.Labels = make(map[string]string)
+		}
+		labels.Labels[Label] = "true"
+	}
+	return rv
+}
+
+// Filter returns nses which are not draining
+func Filter(nses []*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+	var rv []*registry.NetworkServiceEndpoint
+	for _, nse := range nses {
+		if !IsDraining(nse) {
+			rv = append(rv, nse)
+		}
+	}
+	return rv
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exclusionutils provides helpers to exclude an NSE from the selection for a single request. The excluded NSE
+// name is passed in the connection extra context, so it reaches discover in the other processes on the path.
+package exclusionutils
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// Key is the connection extra context key keeping the name of the NSE excluded from the selection
+const Key = "nsm.excluded-nse"
+
+// Exclude marks the NSE with nseName as excluded from the selection for conn
+func Exclude(conn *networkservice.Connection, nseName string) {
+	if conn.GetContext() == nil {
+		conn.Context = new(networkservice.ConnectionContext)
+	}
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.GetContext().ExtraContext = make(map[string]string)
+	}
+	conn.GetContext().GetExtraContext()[Key] = nseName
+}
+
+// Excluded returns the name of the NSE excluded from the selection for conn, or "" if there is no one
+func Excluded(conn *networkservice.Connection) string {
+	return conn.GetContext().GetExtraContext()[Key]
+}
+
+// Clear removes the exclusion from conn
+func Clear(conn *networkservice.Connection) {
+	delete(conn.GetContext().GetExtraContext(), Key)
+}
+
+// Filter returns nses except the one excluded for conn
+func Filter(conn *networkservice.Connection, nses []*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+	excluded := Excluded(conn)
+	if excluded == "" {
+		return nses
+	}
+	var rv []*registry.NetworkServiceEndpoint
+	for _, nse := range nses {
+		if nse.GetName() != excluded {
+			rv = append(rv, nse)
+		}
+	}
+	return rv
+}
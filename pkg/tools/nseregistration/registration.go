@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nseregistration keeps the NSE as last registered, so that the chain elements re-registering the NSE with
+// their own labels (e.g. capacity load, drain mark) don't overwrite each other.
+package nseregistration
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// Registration is the NSE as last registered with the registry client
+type Registration struct {
+	client registry.NetworkServiceEndpointRegistryClient
+
+	mu  sync.Mutex
+	nse *registry.NetworkServiceEndpoint
+}
+
+// New creates a new Registration. client should be the same client the NSE has been registered with, so that its
+// refresh keeps the updates.
+func New(client registry.NetworkServiceEndpointRegistryClient, nse *registry.NetworkServiceEndpoint) *Registration {
+	return &Registration{
+		client: client,
+		nse:    nse.Clone(),
+	}
+}
+
+// NSE returns a copy of the NSE as last registered
+func (r *Registration) NSE() *registry.NetworkServiceEndpoint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.nse.Clone()
+}
+
+// Update re-registers the NSE as last registered modified with the update. Updates are serialized, so each one sees
+// the result of the previous.
+func (r *Registration) Update(ctx context.Context, update func(*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	nse := update(r.nse.Clone())
+	registered, err := r.client.Register(ctx, nse)
+	if err != nil {
+		return errors.Wrapf(err, "failed to register %s", nse.GetName())
+	}
+	if registered == nil {
+		registered = nse
+	}
+	r.nse = registered.Clone()
+	return nil
+}
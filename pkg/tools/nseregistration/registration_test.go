@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nseregistration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/ljkiraly/sdk/pkg/registry/common/memory"
+	registryadapters "github.com/ljkiraly/sdk/pkg/registry/core/adapters"
+	"github.com/ljkiraly/sdk/pkg/tools/capacityutils"
+	"github.com/ljkiraly/sdk/pkg/tools/drainutils"
+	"github.com/ljkiraly/sdk/pkg/tools/nseregistration"
+)
+
+func TestRegistration_KeepsLabels(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	nseClient := registryadapters.NetworkServiceEndpointServerToClient(memory.NewNetworkServiceEndpointRegistryServer())
+	nse, err := nseClient.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{"ns"},
+	})
+	require.NoError(t, err)
+
+	registration := nseregistration.New(nseClient, nse)
+	require.NoError(t, registration.Update(ctx, drainutils.Mark))
+	require.NoError(t, registration.Update(ctx, func(nse *registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+		return capacityutils.WithLoad(nse, 2, 2)
+	}))
+
+	stream, err := nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse"},
+	})
+	require.NoError(t, err)
+	nses := registry.ReadNetworkServiceEndpointList(stream)
+	require.Len(t, nses, 1)
+	require.True(t, drainutils.IsDraining(nses[0]))
+	require.True(t, capacityutils.IsFull(nses[0]))
+	require.True(t, drainutils.IsDraining(registration.NSE()))
+}
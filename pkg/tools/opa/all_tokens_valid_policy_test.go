@@ -23,10 +23,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ljkiraly/sdk/pkg/tools/opa"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
 )
 
 func TestWithAllTokensValidPolicy(t *testing.T) {
@@ -66,3 +68,40 @@ func TestWithAllTokensValidPolicy(t *testing.T) {
 	err = p.Check(context.Background(), invalidPath)
 	require.NotNil(t, err)
 }
+
+func TestWithAllTokensValidPolicy_Revoked(t *testing.T) {
+	path := &networkservice.Path{
+		PathSegments: []*networkservice.PathSegment{
+			{
+				Token: genJWTWithClaims(&jwt.RegisteredClaims{
+					ID:        "token-1",
+					Subject:   "spiffe://test.com/nsc",
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+				}),
+			},
+			{
+				Token: genJWTWithClaims(&jwt.RegisteredClaims{
+					ID:        "token-2",
+					Subject:   "spiffe://test.com/nsmgr",
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+				}),
+			},
+		},
+	}
+
+	p, err := opa.PolicyFromFile("etc/nsm/opa/common/tokens_valid.rego")
+	require.NoError(t, err)
+
+	list := revocation.NewList(nil, nil)
+	ctx := revocation.WithList(context.Background(), list)
+	require.NoError(t, p.Check(ctx, path))
+
+	list.Update(nil, []string{"token-2"})
+	require.Error(t, p.Check(ctx, path))
+
+	list.Update([]string{"spiffe://test.com/nsc"}, nil)
+	require.Error(t, p.Check(ctx, path))
+
+	list.Update([]string{"spiffe://test.com/nse"}, []string{"token-3"})
+	require.NoError(t, p.Check(ctx, path))
+}
@@ -24,33 +24,97 @@ import (
 	"encoding/json"
 	"encoding/pem"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"google.golang.org/grpc/peer"
 
 	"google.golang.org/grpc/credentials"
+
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
 )
 
+// InputVersion is the version of the policy input schema, see README.md for the schema description
+const InputVersion = "v1"
+
 // PreparedOpaInput - converts model to map. It also puts auth_info in root of the map if it is presented in context.
+// If model has "path_segments", the decoded claims of their tokens are put as "token_claims".
+// If revocation.List is presented in context, the revoked IDs of the tokens and the peer are put as "revoked" with
+// "spiffe_ids" and "token_ids" objects keyed by ID.
 func PreparedOpaInput(ctx context.Context, model interface{}) (map[string]interface{}, error) {
 	result, err := convertToMap(model)
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot convert %v to map", model)
 	}
+	result["input_version"] = InputVersion
 	p, ok := peer.FromContext(ctx)
 	var cert *x509.Certificate
 	if ok {
 		cert = ParseX509Cert(p.AuthInfo)
 	}
-	var pemcert string
+	var pemcert, spiffeID, trustDomain string
 	if cert != nil {
 		pemcert = pemEncodingX509Cert(cert)
+		if id, idErr := x509svid.IDFromCert(cert); idErr == nil {
+			spiffeID, trustDomain = id.String(), id.TrustDomain().String()
+		}
 	}
 	result["auth_info"] = map[string]interface{}{
-		"certificate": pemcert,
+		"certificate":  pemcert,
+		"spiffe_id":    spiffeID,
+		"trust_domain": trustDomain,
+	}
+	var claims []interface{}
+	if segments, ok := result["path_segments"].([]interface{}); ok {
+		claims = tokenClaims(segments)
+		result["token_claims"] = claims
+	}
+	if list := revocation.FromContext(ctx); list != nil {
+		result["revoked"] = revoked(list, spiffeID, claims)
 	}
 	return result, nil
 }
 
+// revoked returns the revoked token IDs and SPIFFE IDs of the tokens claims and the peer
+func revoked(list *revocation.List, spiffeID string, claims []interface{}) map[string]interface{} {
+	spiffeIDs := make(map[string]interface{})
+	tokenIDs := make(map[string]interface{})
+	if spiffeID != "" && list.IsSpiffeIDRevoked(spiffeID) {
+		spiffeIDs[spiffeID] = true
+	}
+	for _, c := range claims {
+		m, _ := c.(map[string]interface{})
+		if sub, ok := m["sub"].(string); ok && list.IsSpiffeIDRevoked(sub) {
+			spiffeIDs[sub] = true
+		}
+		if jti, ok := m["jti"].(string); ok && list.IsTokenIDRevoked(jti) {
+			tokenIDs[jti] = true
+		}
+	}
+	return map[string]interface{}{
+		"spiffe_ids": spiffeIDs,
+		"token_ids":  tokenIDs,
+	}
+}
+
+// tokenClaims returns the decoded claims of the segments tokens. Signatures are not verified, claims of the
+// invalid tokens are empty.
+func tokenClaims(segments []interface{}) []interface{} {
+	rv := make([]interface{}, 0, len(segments))
+	for _, segment := range segments {
+		claims := jwt.MapClaims{}
+		if m, ok := segment.(map[string]interface{}); ok {
+			if token, ok := m["token"].(string); ok {
+				if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+					claims = jwt.MapClaims{}
+				}
+			}
+		}
+		rv = append(rv, map[string]interface{}(claims))
+	}
+	return rv
+}
+
 func pemEncodingX509Cert(cert *x509.Certificate) string {
 	certpem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
 	return string(certpem)
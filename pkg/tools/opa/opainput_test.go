@@ -23,11 +23,13 @@ import (
 	"encoding/pem"
 	"testing"
 
+	"github.com/golang-jwt/jwt/v4"
 	"google.golang.org/grpc/peer"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/ljkiraly/sdk/pkg/tools/opa"
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
 
 	"github.com/stretchr/testify/assert"
 
@@ -87,7 +89,13 @@ func TestPreparedOpaInput(t *testing.T) {
 			},
 		},
 		"auth_info": map[string]interface{}{
-			"certificate": certPem,
+			"certificate":  certPem,
+			"spiffe_id":    spiffeID,
+			"trust_domain": "test.com",
+		},
+		"input_version": opa.InputVersion,
+		"token_claims": []interface{}{
+			map[string]interface{}{},
 		},
 	}
 
@@ -95,3 +103,92 @@ func TestPreparedOpaInput(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expectedInput, realInput)
 }
+
+func TestPreparedOpaInput_TokenClaims(t *testing.T) {
+	conn := getConnectionWithToken(genJWTWithClaims(&jwt.RegisteredClaims{
+		ID:      "id",
+		Subject: spiffeID,
+	}))
+
+	realInput, err := opa.PreparedOpaInput(context.Background(), conn.GetPath())
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"jti": "id",
+			"sub": spiffeID,
+		},
+	}, realInput["token_claims"])
+}
+
+func TestPreparedOpaInput_Revoked(t *testing.T) {
+	conn := getConnectionWithToken(genJWTWithClaims(&jwt.RegisteredClaims{
+		ID:      "id",
+		Subject: spiffeID,
+	}))
+
+	list := revocation.NewList([]string{spiffeID, "spiffe://test.com/other"}, []string{"id", "other-id"})
+	realInput, err := opa.PreparedOpaInput(revocation.WithList(context.Background(), list), conn.GetPath())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"spiffe_ids": map[string]interface{}{spiffeID: true},
+		"token_ids":  map[string]interface{}{"id": true},
+	}, realInput["revoked"])
+}
+
+func TestServiceLabelsSamplePolicy(t *testing.T) {
+	policies, err := opa.PoliciesByFileMask("sample_policies/service_labels.rego")
+	assert.Nil(t, err)
+	assert.Len(t, policies, 1)
+
+	input := func(clientID, nseName, team string) map[string]interface{} {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: clientID}).SignedString([]byte("secret"))
+		assert.Nil(t, err)
+		return map[string]interface{}{
+			"path_segments": []interface{}{
+				map[string]interface{}{"token": token},
+			},
+			"network_service_endpoint": map[string]interface{}{
+				"name":   nseName,
+				"labels": map[string]interface{}{"team": team},
+			},
+		}
+	}
+
+	// The peer is not the client, the client is the subject of the first path token
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: &credentials.TLSInfo{
+		State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{genCert(t, "spiffe://example.org/ns/nsm/sa/forwarder")}},
+	}})
+
+	assert.Nil(t, policies[0].Check(ctx, input("spiffe://example.org/ns/team-a/sa/nsc", "nse", "a")))
+	assert.NotNil(t, policies[0].Check(ctx, input("spiffe://example.org/ns/team-a/sa/nsc", "nse", "b")))
+	assert.Nil(t, policies[0].Check(ctx, input("spiffe://example.org/ns/team-a/sa/nsc", "", "")))
+	assert.Nil(t, policies[0].Check(ctx, input("spiffe://example.org/ns/team-b/sa/nsc", "nse", "b")))
+	assert.NotNil(t, policies[0].Check(ctx, map[string]interface{}{}))
+}
+
+func TestCustomSamplePolicy(t *testing.T) {
+	policies, err := opa.PoliciesByFileMask("sample_policies/custom_policy.rego")
+	assert.Nil(t, err)
+	assert.Len(t, policies, 1)
+
+	ctx := func(spiffeID string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: &credentials.TLSInfo{
+			State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{genCert(t, spiffeID)}},
+		}})
+	}
+
+	path := getConnectionWithToken("token").GetPath()
+	assert.Nil(t, policies[0].Check(ctx("spiffe://example.org/ns/team-a/sa/nsc"), path))
+	assert.NotNil(t, policies[0].Check(ctx("spiffe://test.com/ns/team-a/sa/nsc"), path))
+	assert.NotNil(t, policies[0].Check(context.Background(), path))
+}
+
+func genCert(t *testing.T, spiffeID string) *x509.Certificate {
+	ca, err := generateCA()
+	assert.Nil(t, err)
+	keyPair, err := generateKeyPair(spiffeID, "example.org", &ca)
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	assert.Nil(t, err)
+	return cert
+}
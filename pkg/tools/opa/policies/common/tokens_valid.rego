@@ -28,6 +28,16 @@ valid {
 }
 
 token_valid(token) = r {
-    [_, _, _] := io.jwt.decode(token)
+	[_, payload, _] := io.jwt.decode(token)
+	not token_revoked(payload)
 	r := true
-}
\ No newline at end of file
+}
+
+# revoked means token id (jti) or subject is in the revocation list
+token_revoked(payload) {
+	input.revoked.token_ids[payload.jti]
+}
+
+token_revoked(payload) {
+	input.revoked.spiffe_ids[payload.sub]
+}
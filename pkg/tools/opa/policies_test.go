@@ -33,7 +33,7 @@ func TestDefaultPoliciesFromMask(t *testing.T) {
 func TestCustomPolicies(t *testing.T) {
 	policies, err := opa.PoliciesByFileMask("sample_policies/.*.rego")
 	require.NoError(t, err)
-	require.Len(t, policies, 2)
+	require.Len(t, policies, 3)
 }
 
 func TestMaskForPolicyFiles(t *testing.T) {
@@ -51,7 +51,7 @@ func TestReadOnePolicy(t *testing.T) {
 func TestDefaultAndCustomPolicies(t *testing.T) {
 	policies, err := opa.PoliciesByFileMask("policies/.*.rego", "sample_policies/.*.rego")
 	require.NoError(t, err)
-	require.Len(t, policies, 9)
+	require.Len(t, policies, 10)
 }
 
 func TestOverriddenPolicy(t *testing.T) {
@@ -1,3 +1,9 @@
 package test
 
-default valid = true
\ No newline at end of file
+default valid := false
+
+# allows the calls from the peers of the example.org trust domain only
+valid {
+	input.input_version == "v1"
+	input.auth_info.trust_domain == "example.org"
+}
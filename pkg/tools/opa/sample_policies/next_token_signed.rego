@@ -1,3 +1,8 @@
 package test
 
-default valid := true
\ No newline at end of file
+default valid := false
+
+# overrides the default next_token_signed policy: the next token signature is not checked
+valid {
+	input.input_version == "v1"
+}
@@ -0,0 +1,27 @@
+package test
+
+default valid := false
+
+client_ns := "spiffe://example.org/ns/team-a/"
+
+# The client is the subject of the first path token, the token signatures are checked by the tokens_valid policy
+client_id := input.token_claims[0].sub
+
+valid {
+	input.input_version == "v1"
+	is_string(client_id)
+	not startswith(client_id, client_ns)
+}
+
+# The NSE is not selected yet, the hops after the selection check it
+valid {
+	input.input_version == "v1"
+	startswith(client_id, client_ns)
+	input.network_service_endpoint.name == ""
+}
+
+valid {
+	input.input_version == "v1"
+	startswith(client_id, client_ns)
+	input.network_service_endpoint.labels.team == "a"
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/ljkiraly/sdk/pkg/tools/fs"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+// WatchFile keeps limiter in sync with the yaml/json Config file located at filePath.
+// Removing the file disables all the limits. Watching stops when ctx is done.
+func WatchFile(ctx context.Context, filePath string, limiter *Limiter) {
+	logger := log.FromContext(ctx).WithField("ratelimit", "WatchFile")
+
+	update := func(bytes []byte) {
+		config := new(Config)
+		if bytes != nil {
+			if err := yaml.Unmarshal(bytes, config); err != nil {
+				logger.Errorf("can not unmarshal rate limits from %s: %v", filePath, err.Error())
+				return
+			}
+		}
+		if err := limiter.Update(config); err != nil {
+			logger.Errorf("can not update rate limits from %s: %v", filePath, err.Error())
+		}
+	}
+
+	updateCh := fs.WatchFile(ctx, filePath)
+	update(<-updateCh)
+	go func() {
+		for bytes := range updateCh {
+			update(bytes)
+		}
+	}()
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides token-bucket rate limiting and concurrency capping for Request and Register calls
+package ratelimit
+
+import (
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// KeyType is a type of the rate limiting key
+type KeyType string
+
+const (
+	// SpiffeID - key is the peer SPIFFE ID
+	SpiffeID KeyType = "spiffe_id"
+	// NetworkService - key is the network service name
+	NetworkService KeyType = "network_service"
+	// NetworkServiceEndpoint - key is the network service endpoint name
+	NetworkServiceEndpoint KeyType = "network_service_endpoint"
+)
+
+// Rule is a token-bucket limit applied to every distinct non-empty key of type Key matching Pattern
+type Rule struct {
+	// Key is the type of the key the rule is applied to
+	Key KeyType `json:"key"`
+	// Pattern is a regexp the key should fully match, empty pattern matches any key
+	Pattern string `json:"pattern"`
+	// Rate is the number of allowed calls per second, should be positive
+	Rate float64 `json:"rate"`
+	// Burst is the maximum number of calls allowed at once, should be positive
+	Burst int `json:"burst"`
+}
+
+// Config is a configuration of the Limiter
+type Config struct {
+	// Rules are applied all together, a call is throttled if any of the matching rules is exceeded
+	Rules []*Rule `json:"rules"`
+	// MaxConcurrent is a global cap of the calls being processed at the same time, 0 means no cap
+	MaxConcurrent int64 `json:"max_concurrent"`
+}
+
+// sweepInterval is a minimum interval between the idle bucket sweeps
+const sweepInterval = time.Minute
+
+type compiledRule struct {
+	*Rule
+	re *regexp.Regexp
+	// refill is the time an empty bucket takes to refill completely
+	refill time.Duration
+}
+
+type bucketKey struct {
+	index int
+	key   string
+}
+
+type bucket struct {
+	*rate.Limiter
+	lastUsed time.Time
+}
+
+type limiterState struct {
+	rules         []*compiledRule
+	maxConcurrent int64
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+// Limiter is a thread safe reloadable rate limiter
+type Limiter struct {
+	state      atomic.Value
+	concurrent int64
+}
+
+// NewLimiter creates a new Limiter from config
+func NewLimiter(config *Config) (*Limiter, error) {
+	l := new(Limiter)
+	if err := l.Update(config); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Update replaces the config of the limiter. All the token buckets are reset. Returns an error and keeps the current
+// config if the new one is invalid.
+func (l *Limiter) Update(config *Config) error {
+	state := &limiterState{
+		buckets: make(map[bucketKey]*bucket),
+	}
+	if config != nil {
+		state.maxConcurrent = config.MaxConcurrent
+		for _, rule := range config.Rules {
+			if rule.Rate <= 0 {
+				return errors.Errorf("rate should be positive: %v", rule.Rate)
+			}
+			if rule.Burst <= 0 {
+				return errors.Errorf("burst should be positive: %v", rule.Burst)
+			}
+			pattern := rule.Pattern
+			if pattern == "" {
+				pattern = ".*"
+			}
+			re, err := regexp.Compile("^(" + pattern + ")$")
+			if err != nil {
+				return errors.Wrapf(err, "failed to compile pattern: %s", rule.Pattern)
+			}
+			refill := time.Duration(float64(rule.Burst) / rule.Rate * float64(time.Second))
+			state.rules = append(state.rules, &compiledRule{Rule: rule, re: re, refill: refill})
+		}
+	}
+	l.state.Store(state)
+	return nil
+}
+
+// Acquire checks the rules matching keys at the moment now and takes a concurrency slot.
+// Returns ResourceExhausted error if the call should be throttled. Otherwise release must be called once the call
+// is finished.
+func (l *Limiter) Acquire(now time.Time, keys map[KeyType]string) (release func(), err error) {
+	state := l.state.Load().(*limiterState)
+
+	if state.maxConcurrent > 0 {
+		if atomic.AddInt64(&l.concurrent, 1) > state.maxConcurrent {
+			atomic.AddInt64(&l.concurrent, -1)
+			return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent calls: %d", state.maxConcurrent)
+		}
+		release = func() { atomic.AddInt64(&l.concurrent, -1) }
+	} else {
+		release = func() {}
+	}
+
+	if err := state.reserve(now, keys); err != nil {
+		release()
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// reserve takes a token from every bucket matching keys. If any of them is empty, the tokens taken from the others
+// are returned.
+func (s *limiterState) reserve(now time.Time, keys map[KeyType]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	var reservations []*rate.Reservation
+	for i, rule := range s.rules {
+		key, ok := keys[rule.Key]
+		// Empty key is not set yet (e.g. the endpoint before selection), it shouldn't share a bucket among the callers
+		if !ok || key == "" || !rule.re.MatchString(key) {
+			continue
+		}
+		b, ok := s.buckets[bucketKey{i, key}]
+		if !ok {
+			b = &bucket{Limiter: rate.NewLimiter(rate.Limit(rule.Rate), rule.Burst)}
+			s.buckets[bucketKey{i, key}] = b
+		}
+		b.lastUsed = now
+
+		r := b.ReserveN(now, 1)
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			r.CancelAt(now)
+			for _, reservation := range reservations {
+				reservation.CancelAt(now)
+			}
+			return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s: %s", rule.Key, key)
+		}
+		reservations = append(reservations, r)
+	}
+	return nil
+}
+
+// sweep removes the buckets not used for the time they take to refill completely. Such buckets are full, so
+// removing them doesn't change the limits.
+func (s *limiterState) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for k, b := range s.buckets {
+		if now.Sub(b.lastUsed) >= s.rules[k.index].refill {
+			delete(s.buckets, k)
+		}
+	}
+}
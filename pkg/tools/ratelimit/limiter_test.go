@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ljkiraly/sdk/pkg/tools/ratelimit"
+)
+
+func requireResourceExhausted(t *testing.T, err error) {
+	require.Error(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestLimiter_Rate(t *testing.T) {
+	limiter, err := ratelimit.NewLimiter(&ratelimit.Config{
+		Rules: []*ratelimit.Rule{
+			{Key: ratelimit.SpiffeID, Pattern: "spiffe://test.com/nsc-.*", Rate: 1, Burst: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	nsc1 := map[ratelimit.KeyType]string{ratelimit.SpiffeID: "spiffe://test.com/nsc-1"}
+	nsc2 := map[ratelimit.KeyType]string{ratelimit.SpiffeID: "spiffe://test.com/nsc-2"}
+	nse := map[ratelimit.KeyType]string{ratelimit.SpiffeID: "spiffe://test.com/nse"}
+
+	for i := 0; i < 2; i++ {
+		release, acquireErr := limiter.Acquire(now, nsc1)
+		require.NoError(t, acquireErr)
+		release()
+	}
+	_, err = limiter.Acquire(now, nsc1)
+	requireResourceExhausted(t, err)
+
+	_, err = limiter.Acquire(now, nsc2)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, err = limiter.Acquire(now, nse)
+		require.NoError(t, err)
+	}
+
+	_, err = limiter.Acquire(now.Add(time.Second), nsc1)
+	require.NoError(t, err)
+}
+
+func TestLimiter_MaxConcurrent(t *testing.T) {
+	limiter, err := ratelimit.NewLimiter(&ratelimit.Config{
+		MaxConcurrent: 1,
+	})
+	require.NoError(t, err)
+
+	release, err := limiter.Acquire(time.Now(), nil)
+	require.NoError(t, err)
+
+	_, err = limiter.Acquire(time.Now(), nil)
+	requireResourceExhausted(t, err)
+
+	release()
+
+	_, err = limiter.Acquire(time.Now(), nil)
+	require.NoError(t, err)
+}
+
+func TestLimiter_Update(t *testing.T) {
+	limiter, err := ratelimit.NewLimiter(&ratelimit.Config{
+		Rules: []*ratelimit.Rule{
+			{Key: ratelimit.NetworkService, Rate: 1, Burst: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	keys := map[ratelimit.KeyType]string{ratelimit.NetworkService: "ns"}
+	now := time.Now()
+
+	_, err = limiter.Acquire(now, keys)
+	require.NoError(t, err)
+	_, err = limiter.Acquire(now, keys)
+	requireResourceExhausted(t, err)
+
+	require.Error(t, limiter.Update(&ratelimit.Config{
+		Rules: []*ratelimit.Rule{
+			{Key: ratelimit.NetworkService, Pattern: "(", Rate: 1, Burst: 1},
+		},
+	}))
+	require.Error(t, limiter.Update(&ratelimit.Config{
+		Rules: []*ratelimit.Rule{
+			{Key: ratelimit.NetworkService, Rate: 0, Burst: 1},
+		},
+	}))
+	require.Error(t, limiter.Update(&ratelimit.Config{
+		Rules: []*ratelimit.Rule{
+			{Key: ratelimit.NetworkService, Rate: 1, Burst: 0},
+		},
+	}))
+	require.NoError(t, limiter.Update(nil))
+
+	_, err = limiter.Acquire(now, keys)
+	require.NoError(t, err)
+}
+
+func TestLimiter_RejectReturnsTokens(t *testing.T) {
+	limiter, err := ratelimit.NewLimiter(&ratelimit.Config{
+		Rules: []*ratelimit.Rule{
+			{Key: ratelimit.SpiffeID, Rate: 1, Burst: 2},
+			{Key: ratelimit.NetworkService, Rate: 1, Burst: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	nsc := map[ratelimit.KeyType]string{ratelimit.SpiffeID: "spiffe://test.com/nsc"}
+	nscToNS := map[ratelimit.KeyType]string{
+		ratelimit.SpiffeID:       "spiffe://test.com/nsc",
+		ratelimit.NetworkService: "ns",
+	}
+
+	_, err = limiter.Acquire(now, nscToNS)
+	require.NoError(t, err)
+
+	// Rejected by the network service rule, the token is returned to the SPIFFE ID bucket
+	for i := 0; i < 5; i++ {
+		_, err = limiter.Acquire(now, nscToNS)
+		requireResourceExhausted(t, err)
+	}
+
+	_, err = limiter.Acquire(now, nsc)
+	require.NoError(t, err)
+	_, err = limiter.Acquire(now, nsc)
+	requireResourceExhausted(t, err)
+}
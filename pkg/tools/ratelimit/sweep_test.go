@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_SweepIdleBuckets(t *testing.T) {
+	limiter, err := NewLimiter(&Config{
+		Rules: []*Rule{
+			{Key: SpiffeID, Rate: 1, Burst: 100},
+		},
+	})
+	require.NoError(t, err)
+
+	state := limiter.state.Load().(*limiterState)
+	now := time.Now()
+
+	for i := 0; i < 100; i++ {
+		_, err = limiter.Acquire(now, map[KeyType]string{SpiffeID: fmt.Sprintf("spiffe://test.com/nsc-%d", i)})
+		require.NoError(t, err)
+	}
+	require.Len(t, state.buckets, 100)
+
+	// Idle, but not refilled yet
+	now = now.Add(sweepInterval)
+	_, err = limiter.Acquire(now, nil)
+	require.NoError(t, err)
+	require.Len(t, state.buckets, 100)
+
+	// Refilled and idle
+	now = now.Add(sweepInterval)
+	_, err = limiter.Acquire(now, map[KeyType]string{SpiffeID: "spiffe://test.com/nsc-0"})
+	require.NoError(t, err)
+	require.Len(t, state.buckets, 1)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package refreshutils provides helpers to spread refreshes of connections and registrations in time: jitter, a
+// per-process refresh rate limiter and the refresh lag metric
+package refreshutils
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/opentelemetry"
+)
+
+const refreshLagMetric = "refresh_lag_ms"
+
+// Jitter returns d randomly shortened by up to fraction of d. The result is never later than d, so the refresh is
+// never delayed by the jitter.
+func Jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || d <= 0 {
+		return d
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	// Jitter only spreads the refreshes in time, it is not security-sensitive
+	// #nosec G404
+	return d - time.Duration(rand.Float64()*fraction*float64(d))
+}
+
+// Limiter limits the rate of refreshes in the process. Each refresh takes the next free slot, but never waits past its
+// deadline, so the refreshes are spread over their safe windows instead of firing in lockstep.
+type Limiter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	next     time.Time
+}
+
+// NewLimiter - returns a new Limiter allowing up to rate refreshes per second
+func NewLimiter(rate float64) *Limiter {
+	l := new(Limiter)
+	if rate > 0 {
+		l.interval = time.Duration(float64(time.Second) / rate)
+	}
+	return l
+}
+
+// Wait waits for the next free slot, but not after deadline. Returns the time spent waiting or ctx error.
+// Nil Limiter doesn't wait.
+func (l *Limiter) Wait(ctx context.Context, deadline time.Time) (time.Duration, error) {
+	if l == nil || l.interval == 0 {
+		return 0, nil
+	}
+
+	clockTime := clock.FromContext(ctx)
+	now := clockTime.Now()
+
+	l.mu.Lock()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	if slot.After(deadline) {
+		slot = deadline
+	}
+	if slot.Before(now) {
+		slot = now
+	}
+	// The slot behind the deadline is taken anyway, the next refresh is scheduled after the latest one
+	if next := slot.Add(l.interval); next.After(l.next) {
+		l.next = next
+	}
+	l.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return 0, nil
+	}
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-clockTime.After(delay):
+		return delay, nil
+	}
+}
+
+var (
+	lagOnce      sync.Once
+	lagHistogram metric.Float64Histogram
+)
+
+// RecordLag records the refresh lag: the time between the scheduled refresh and the actual one
+func RecordLag(ctx context.Context, kind string, lag time.Duration) {
+	if !opentelemetry.IsEnabled() {
+		return
+	}
+	lagOnce.Do(func() {
+		var err error
+		lagHistogram, err = otel.Meter("").Float64Histogram(refreshLagMetric,
+			metric.WithDescription("time between the scheduled refresh and the actual one"),
+			metric.WithUnit("ms"))
+		if err != nil {
+			log.FromContext(ctx).Warnf("failed to create %s histogram: %s", refreshLagMetric, err.Error())
+		}
+	})
+	if lagHistogram == nil {
+		return
+	}
+	lagHistogram.Record(ctx, float64(lag)/float64(time.Millisecond), metric.WithAttributes(attribute.String("kind", kind)))
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refreshutils_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/clockmock"
+	"github.com/ljkiraly/sdk/pkg/tools/refreshutils"
+)
+
+func TestJitter(t *testing.T) {
+	require.Equal(t, time.Minute, refreshutils.Jitter(time.Minute, 0))
+	for i := 0; i < 100; i++ {
+		d := refreshutils.Jitter(time.Minute, 0.2)
+		require.LessOrEqual(t, d, time.Minute)
+		require.GreaterOrEqual(t, d, time.Minute*8/10)
+	}
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	limiter := refreshutils.NewLimiter(10)
+	deadline := clockMock.Now().Add(time.Second / 4)
+
+	results := make(chan time.Duration, 5)
+	var started sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		started.Add(1)
+		go func() {
+			started.Done()
+			delay, err := limiter.Wait(ctx, deadline)
+			require.NoError(t, err)
+			results <- delay
+		}()
+	}
+	started.Wait()
+
+	var delays []time.Duration
+	require.Eventually(t, func() bool {
+		clockMock.Add(time.Millisecond * 10)
+		for {
+			select {
+			case delay := <-results:
+				delays = append(delays, delay)
+			default:
+				return len(delays) == 5
+			}
+		}
+	}, time.Second, time.Millisecond)
+
+	// Slots are 100ms apart, but no one waits past the deadline
+	require.ElementsMatch(t, []time.Duration{
+		0,
+		time.Second / 10,
+		time.Second / 5,
+		time.Second / 4,
+		time.Second / 4,
+	}, delays)
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"context"
+)
+
+type contextKeyType struct{}
+
+// WithList wraps parent in a new context with List
+func WithList(parent context.Context, list *List) context.Context {
+	if parent == nil {
+		panic("cannot create context from nil parent")
+	}
+	return context.WithValue(parent, contextKeyType{}, list)
+}
+
+// FromContext returns List from context or nil if it is not presented
+func FromContext(ctx context.Context) *List {
+	if rv, ok := ctx.Value(contextKeyType{}).(*List); ok {
+		return rv
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"context"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/ljkiraly/sdk/pkg/tools/fs"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+// Config is a file representation of the List
+type Config struct {
+	SpiffeIDs []string `json:"spiffe_ids"`
+	TokenIDs  []string `json:"token_ids"`
+}
+
+// WatchFile keeps list in sync with the yaml/json config file located at filePath.
+// Removing the file clears the list. Watching stops when ctx is done.
+func WatchFile(ctx context.Context, filePath string, list *List) {
+	logger := log.FromContext(ctx).WithField("revocation", "WatchFile")
+
+	update := func(bytes []byte) {
+		var config Config
+		if bytes != nil {
+			if err := yaml.Unmarshal(bytes, &config); err != nil {
+				logger.Errorf("can not unmarshal revocation list from %s: %v", filePath, err.Error())
+				return
+			}
+		}
+		list.Update(config.SpiffeIDs, config.TokenIDs)
+	}
+
+	updateCh := fs.WatchFile(ctx, filePath)
+	update(<-updateCh)
+	go func() {
+		for bytes := range updateCh {
+			update(bytes)
+		}
+	}()
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package revocation provides a deny-list of revoked SPIFFE IDs and token IDs (jti)
+package revocation
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// List is a thread safe deny-list of revoked SPIFFE IDs and token IDs
+type List struct {
+	mu          sync.RWMutex
+	spiffeIDs   map[string]struct{}
+	tokenIDs    map[string]struct{}
+	subscribers map[chan struct{}]struct{}
+}
+
+// NewList creates a new List filled with the passed SPIFFE IDs and token IDs
+func NewList(spiffeIDs, tokenIDs []string) *List {
+	l := &List{
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+	l.spiffeIDs, l.tokenIDs = toSet(spiffeIDs), toSet(tokenIDs)
+	return l
+}
+
+// Update replaces the content of the list and notifies all subscribers
+func (l *List) Update(spiffeIDs, tokenIDs []string) {
+	l.mu.Lock()
+	l.spiffeIDs, l.tokenIDs = toSet(spiffeIDs), toSet(tokenIDs)
+	l.mu.Unlock()
+
+	l.notify()
+}
+
+// Revoke adds the passed SPIFFE IDs and token IDs to the list and notifies all subscribers
+func (l *List) Revoke(spiffeIDs, tokenIDs []string) {
+	l.mu.Lock()
+	for _, id := range spiffeIDs {
+		l.spiffeIDs[id] = struct{}{}
+	}
+	for _, id := range tokenIDs {
+		l.tokenIDs[id] = struct{}{}
+	}
+	l.mu.Unlock()
+
+	l.notify()
+}
+
+// IsSpiffeIDRevoked returns true if spiffeID is in the list
+func (l *List) IsSpiffeIDRevoked(spiffeID string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	_, ok := l.spiffeIDs[spiffeID]
+	return ok
+}
+
+// IsTokenIDRevoked returns true if tokenID is in the list
+func (l *List) IsTokenIDRevoked(tokenID string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	_, ok := l.tokenIDs[tokenID]
+	return ok
+}
+
+// SpiffeIDs returns sorted revoked SPIFFE IDs
+func (l *List) SpiffeIDs() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return fromSet(l.spiffeIDs)
+}
+
+// TokenIDs returns sorted revoked token IDs
+func (l *List) TokenIDs() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return fromSet(l.tokenIDs)
+}
+
+// CheckToken returns PermissionDenied error if the subject or the ID of the passed JWT token is revoked.
+// The token signature is not verified, it is the responsibility of the authorization policies.
+func (l *List) CheckToken(token string) error {
+	if token == "" {
+		return nil
+	}
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		return errors.Wrap(err, "failed to parse jwt token")
+	}
+	if claims.ID != "" && l.IsTokenIDRevoked(claims.ID) {
+		return status.Errorf(codes.PermissionDenied, "token %s is revoked", claims.ID)
+	}
+	if claims.Subject != "" && l.IsSpiffeIDRevoked(claims.Subject) {
+		return status.Errorf(codes.PermissionDenied, "spiffe id %s is revoked", claims.Subject)
+	}
+	return nil
+}
+
+// Subscribe returns a channel receiving a value each time the list is changed.
+// The channel is closed when ctx is done.
+func (l *List) Subscribe(ctx context.Context) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	l.mu.Lock()
+	l.subscribers[ch] = struct{}{}
+	l.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		l.mu.Lock()
+		delete(l.subscribers, ch)
+		l.mu.Unlock()
+
+		close(ch)
+	}()
+
+	return ch
+}
+
+func (l *List) notify() {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for ch := range l.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func toSet(ids []string) map[string]struct{} {
+	rv := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if id != "" {
+			rv[id] = struct{}{}
+		}
+	}
+	return rv
+}
+
+func fromSet(set map[string]struct{}) []string {
+	rv := make([]string, 0, len(set))
+	for id := range set {
+		rv = append(rv, id)
+	}
+	sort.Strings(rv)
+	return rv
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/ljkiraly/sdk/pkg/tools/revocation"
+)
+
+func genJWT(id, subject string) string {
+	t, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.RegisteredClaims{
+		ID:      id,
+		Subject: subject,
+	}).SignedString([]byte("super secret"))
+	return t
+}
+
+func TestList_CheckToken(t *testing.T) {
+	list := revocation.NewList([]string{"spiffe://test.com/nsc"}, []string{"token-1"})
+
+	require.Error(t, list.CheckToken(genJWT("token-1", "spiffe://test.com/nse")))
+	require.Error(t, list.CheckToken(genJWT("token-2", "spiffe://test.com/nsc")))
+	require.NoError(t, list.CheckToken(genJWT("token-2", "spiffe://test.com/nse")))
+	require.NoError(t, list.CheckToken(""))
+	require.Error(t, list.CheckToken("invalid token"))
+}
+
+func TestList_Subscribe(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	list := revocation.NewList(nil, nil)
+	ch := list.Subscribe(ctx)
+
+	list.Revoke([]string{"spiffe://test.com/nsc"}, nil)
+	require.Eventually(t, func() bool { return len(ch) == 1 }, time.Second, time.Millisecond*10)
+	<-ch
+
+	require.Equal(t, []string{"spiffe://test.com/nsc"}, list.SpiffeIDs())
+	require.Empty(t, list.TokenIDs())
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, time.Second, time.Millisecond*10)
+}
+
+func TestWatchFile(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	filePath := filepath.Join(t.TempDir(), "revoked.yaml")
+	require.NoError(t, os.WriteFile(filePath, []byte("spiffe_ids:\n  - spiffe://test.com/nsc\n"), os.ModePerm))
+
+	list := revocation.NewList(nil, nil)
+	revocation.WatchFile(ctx, filePath, list)
+	require.True(t, list.IsSpiffeIDRevoked("spiffe://test.com/nsc"))
+
+	require.NoError(t, os.WriteFile(filePath, []byte("token_ids:\n  - token-1\n"), os.ModePerm))
+	require.Eventually(t, func() bool {
+		return list.IsTokenIDRevoked("token-1") && !list.IsSpiffeIDRevoked("spiffe://test.com/nsc")
+	}, time.Second, time.Millisecond*10)
+
+	require.NoError(t, os.Remove(filePath))
+	require.Eventually(t, func() bool {
+		return len(list.TokenIDs()) == 0
+	}, time.Second, time.Millisecond*10)
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"google.golang.org/grpc/credentials"
@@ -42,6 +43,7 @@ func TokenGeneratorFunc(source x509svid.Source, maxTokenLifeTime time.Duration)
 			expireTime = ownSVID.Certificates[0].NotAfter
 		}
 		claims := jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			Subject:   ownSVID.ID.String(),
 			ExpiresAt: jwt.NewNumericDate(expireTime),
 		}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenant provides tools to isolate registry entries of different tenants sharing one registry
+package tenant
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+type contextKeyType struct{}
+
+// WithTenant wraps parent in a new context with tenant
+func WithTenant(parent context.Context, tenant string) context.Context {
+	if parent == nil {
+		panic("cannot create context from nil parent")
+	}
+	return context.WithValue(parent, contextKeyType{}, tenant)
+}
+
+// FromContext returns tenant from context. Empty tenant means the shared scope.
+func FromContext(ctx context.Context) string {
+	if rv, ok := ctx.Value(contextKeyType{}).(string); ok {
+		return rv
+	}
+	return ""
+}
+
+// ScopedKey returns the key of the entry with name registered by tenant. Entries of different tenants may have the
+// same name, so the chain elements keeping per entry state should use the scoped key.
+func ScopedKey(tenant, name string) string {
+	if tenant == "" {
+		return name
+	}
+	return tenant + "\x00" + name
+}
+
+// SplitScopedKey returns the tenant and the name of the key created by ScopedKey
+func SplitScopedKey(key string) (tenant, name string) {
+	if i := strings.IndexByte(key, '\x00'); i >= 0 {
+		return key[:i], key[i+1:]
+	}
+	return "", key
+}
+
+// Func derives tenant from the caller SPIFFE ID
+type Func func(id spiffeid.ID) string
+
+// TrustDomain returns Func using the trust domain of the SPIFFE ID as tenant
+func TrustDomain() Func {
+	return func(id spiffeid.ID) string {
+		return id.TrustDomain().String()
+	}
+}
+
+// PathSegment returns Func using the path segment with the passed index of the SPIFFE ID as tenant.
+// E.g. PathSegment(1) returns "tenant-a" for "spiffe://example.org/ns/tenant-a/sa/nse".
+func PathSegment(index int) Func {
+	return func(id spiffeid.ID) string {
+		segments := strings.Split(strings.TrimPrefix(id.Path(), "/"), "/")
+		if index < 0 || index >= len(segments) {
+			return ""
+		}
+		return segments[index]
+	}
+}
+
+// ExportPolicy returns true if the entry with name owned by owner tenant is allowed to be seen by consumer tenant
+type ExportPolicy func(owner, consumer, name string) bool
+
+// Export allows Consumers to see entries of Owner tenant with names matching Name regexp
+type Export struct {
+	Owner     string   `json:"owner"`
+	Name      string   `json:"name"`
+	Consumers []string `json:"consumers"`
+}
+
+// NewExportPolicy creates ExportPolicy allowing only the passed exports. "*" consumer means any tenant.
+func NewExportPolicy(exports ...*Export) ExportPolicy {
+	type compiledExport struct {
+		*Export
+		re *regexp.Regexp
+	}
+	var compiled []*compiledExport
+	for _, export := range exports {
+		compiled = append(compiled, &compiledExport{
+			Export: export,
+			re:     regexp.MustCompile("^(" + export.Name + ")$"),
+		})
+	}
+	return func(owner, consumer, name string) bool {
+		for _, export := range compiled {
+			if export.Owner != owner || !export.re.MatchString(name) {
+				continue
+			}
+			for _, c := range export.Consumers {
+				if c == consumer || c == "*" {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// IsVisible returns true if the entry with name owned by owner tenant can be seen by consumer tenant.
+// Entries of the shared scope are visible for everyone, entries of other tenants are visible only if policy allows it.
+func IsVisible(policy ExportPolicy, owner, consumer, name string) bool {
+	if owner == "" || owner == consumer {
+		return true
+	}
+	return policy != nil && policy(owner, consumer, name)
+}
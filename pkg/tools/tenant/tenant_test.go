@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant_test
+
+import (
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/tools/tenant"
+)
+
+func TestFuncs(t *testing.T) {
+	id := spiffeid.RequireFromString("spiffe://example.org/ns/tenant-a/sa/nse")
+
+	require.Equal(t, "example.org", tenant.TrustDomain()(id))
+	require.Equal(t, "tenant-a", tenant.PathSegment(1)(id))
+	require.Equal(t, "", tenant.PathSegment(10)(id))
+}
+
+func TestIsVisible(t *testing.T) {
+	policy := tenant.NewExportPolicy(
+		&tenant.Export{Owner: "a", Name: "public-.*", Consumers: []string{"b"}},
+		&tenant.Export{Owner: "a", Name: "global", Consumers: []string{"*"}},
+	)
+
+	require.True(t, tenant.IsVisible(nil, "", "a", "nse"))
+	require.True(t, tenant.IsVisible(nil, "a", "a", "nse"))
+	require.False(t, tenant.IsVisible(nil, "a", "b", "nse"))
+	require.False(t, tenant.IsVisible(nil, "a", "", "nse"))
+
+	require.True(t, tenant.IsVisible(policy, "a", "b", "public-nse"))
+	require.False(t, tenant.IsVisible(policy, "a", "c", "public-nse"))
+	require.False(t, tenant.IsVisible(policy, "a", "b", "nse"))
+	require.True(t, tenant.IsVisible(policy, "a", "c", "global"))
+}